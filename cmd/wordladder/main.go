@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cheilman/go-wordladder/wordladder"
+)
+
+const wordFile = "/usr/share/dict/words"
+const forestGraphFile = "wordForest.json.gz"
+
+var wordGraph *wordladder.WordGraph
+
+var dictFlag = flag.String("dict", wordFile, "path to the dictionary word list")
+var forestFlag = flag.String("forest", forestGraphFile, "path to the pre-processed forest graph")
+var ignoreCaseFlag = flag.Bool("ignore-case", false, "lowercase dictionary words and queries")
+var jsonFlag = flag.Bool("json", false, "print one JSON object per query instead of text")
+var pairsFlag = flag.String("pairs", "", "file of \"word1 word2\" lines to solve, or - for stdin")
+var interactiveFlag = flag.Bool("interactive", false, "solve \"word1 word2\" lines from stdin (same as -pairs -)")
+var statsFlag = flag.Bool("stats", false, "print connectivity stats for the dictionary and exit")
+var minLenFlag = flag.Int("minlen", 0, "skip dictionary words shorter than this (0 for no limit)")
+var maxLenFlag = flag.Int("maxlen", 0, "skip dictionary words longer than this (0 for no limit)")
+
+// Where loading progress goes.  Kept off stdout in -json mode so the output stays parseable.
+var status io.Writer = os.Stdout
+
+// One query's answer, for -json output
+type result struct {
+	From      string   `json:"from"`
+	To        string   `json:"to"`
+	Connected bool     `json:"connected"`
+	Path      []string `json:"path"`
+	Steps     int      `json:"steps"`
+}
+
+func main() {
+	flag.Parse()
+
+	if *jsonFlag {
+		status = os.Stderr
+	}
+
+	//
+	// Load all words into a fresh graph.  That's quick, it's exploring them that takes a while.
+	//
+
+	var fresh = wordladder.NewWordGraph()
+	applyOptions(fresh)
+
+	fmt.Fprintf(status, "Loading words from %v.\n", *dictFlag)
+
+	// Open the file (gzipped is fine).  If it's the default and it isn't there, use the built-in list instead.
+	var dict io.Reader
+	var f, err = wordladder.OpenDictionary(*dictFlag)
+	if err == nil {
+		defer f.Close()
+		dict = f
+	} else if flagWasSet("dict") {
+		fmt.Fprintf(os.Stderr, "Unable to open dictionary: %v\n", err)
+		os.Exit(1)
+	} else {
+		fmt.Fprintf(status, "No dictionary at %v, using the built-in word list.\n", *dictFlag)
+		dict = wordladder.DefaultDictionary()
+	}
+
+	// Read each word into the graph
+	if _, err := fresh.LoadFromReader(dict); err != nil {
+		panic(err)
+	}
+
+	//
+	// See if we have a pre-processed forest graph of the same words
+	//
+
+	loaded, err := wordladder.LoadForestFor(*forestFlag, fresh.DictionaryHash())
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(status, "Unable to read pre-processed graph from %v (%v), rebuilding it.\n", *forestFlag, err)
+		}
+
+		wordGraph = fresh
+
+		//
+		// Start assigning forests and neighbors
+		//
+		fmt.Fprintf(status, "Assigning forests and analyzing neighbors.  There are %v distinct word lengths.\n", wordGraph.GetTotalDistinctWordLengths())
+
+		wordGraph.ExploreForests()
+
+		fmt.Fprintf(status, "Assigned %v words into %v forests.\n", wordGraph.GetTotalWords(), wordGraph.GetTotalForests())
+
+		//
+		// Serialize forest map
+		//
+
+		if strings.HasSuffix(*forestFlag, ".gz") {
+			if err := wordGraph.SaveGzip(*forestFlag); err != nil {
+				panic(err)
+			}
+		} else {
+			forestFile, err := os.Create(*forestFlag)
+			if err != nil {
+				panic(err)
+			}
+
+			// Dump it to JSON
+			if err := wordGraph.WriteJSON(forestFile); err != nil {
+				panic(err)
+			}
+			forestFile.Close()
+		}
+	} else {
+
+		//
+		// Load the pre-processed graph into memory
+		//
+
+		fmt.Fprintf(status, "Read pre-processed graph from %v.\n", *forestFlag)
+
+		wordGraph = loaded
+		applyOptions(wordGraph)
+
+		// And let's just make sure it all worked
+		fmt.Fprintf(status, "Loaded pre-processed forest graph.  %v distinct word lengths in graph.\n", wordGraph.GetTotalDistinctWordLengths())
+
+		for _, subgraph := range wordGraph.Graphs {
+			fmt.Fprintf(status, "There are %v words of size %v.\n", subgraph.GetTotalWords(), subgraph.WordLength)
+		}
+	}
+
+	if *statsFlag {
+		printStats(os.Stdout, wordGraph)
+		return
+	}
+
+	//
+	// Solve the user's pairs, if they gave us some
+	//
+	if *interactiveFlag {
+		*pairsFlag = "-"
+	}
+
+	if *pairsFlag != "" {
+		var in io.Reader = os.Stdin
+
+		if *pairsFlag != "-" {
+			f, err := os.Open(*pairsFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Unable to open pairs: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+
+			in = f
+		}
+
+		solvePairs(in)
+		return
+	}
+
+	//
+	// Run some tests
+	//
+	var pairs = [][]string{{"cat", "dog"}, {"ape", "man"}, {"pig", "sty"}, {"pen", "ink"}, {"one", "two"}, {"bat", "cry"},
+		{"goat", "fish"}, {"bake", "farm"}, {"lawn", "brat"},
+		{"snake", "cards"}, {"plant", "graph"}}
+
+	if *jsonFlag {
+		var encoder = json.NewEncoder(os.Stdout)
+
+		for _, p := range pairs {
+			for _, query := range [][]string{{p[0], p[1]}, {p[1], p[0]}} {
+				if err := encoder.Encode(solve(query[0], query[1])); err != nil {
+					panic(err)
+				}
+			}
+		}
+
+		return
+	}
+
+	for _, p := range pairs {
+		var s1, s2 = p[0], p[1]
+		fmt.Printf("%v -> %v: ", s1, s2)
+		fmt.Printf("%v\n", wordGraph.AreTwoWordsConnected(s1, s2))
+		fmt.Printf("%v -> %v: ", s2, s1)
+		fmt.Printf("%v\n", wordGraph.AreTwoWordsConnected(s2, s1))
+	}
+
+	fmt.Printf("\n")
+
+	for _, p := range pairs {
+		var s1, s2 = p[0], p[1]
+		fmt.Printf("%v -> %v: %v\n", s1, s2, wordGraph.ShortestPath(s1, s2))
+		fmt.Printf("%v -> %v: %v\n", s2, s1, wordGraph.ShortestPath(s2, s1))
+	}
+
+}
+
+// Report the size and connectivity of the graph: totals, then the largest forest and isolated words per length
+func printStats(w io.Writer, g *wordladder.WordGraph) {
+	var stats = g.Stats()
+	var isolated = g.IsolatedWords()
+
+	fmt.Fprintf(w, "Words: %v\n", stats.TotalWords)
+	fmt.Fprintf(w, "Forests: %v\n", stats.TotalForests)
+	fmt.Fprintf(w, "Distinct lengths: %v\n", stats.DistinctLengths)
+	fmt.Fprintf(w, "Edges: %v\n", stats.TotalEdges)
+
+	var lengths = make([]int, 0, len(stats.WordsByLength))
+	for l := range stats.WordsByLength {
+		lengths = append(lengths, l)
+	}
+	sort.Ints(lengths)
+
+	for _, l := range lengths {
+		var _, largest = g.LargestForest(l)
+		fmt.Fprintf(w, "Length %v: %v words, largest forest %v, %v isolated\n", l, stats.WordsByLength[l], largest, len(isolated[l]))
+	}
+}
+
+// Set up graph options from the command line
+func applyOptions(g *wordladder.WordGraph) {
+	g.UseWildcardIndex = true
+	g.IgnoreCase = *ignoreCaseFlag
+	g.MinLength = *minLenFlag
+	g.MaxLength = *maxLenFlag
+	g.Log = status
+}
+
+// Did the user pass this flag on the command line?
+func flagWasSet(name string) bool {
+	var retval = false
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			retval = true
+		}
+	})
+
+	return retval
+}
+
+// Solve each "word1 word2" line from in until EOF.  Bad lines are reported on stderr and skipped.
+func solvePairs(in io.Reader) {
+	var encoder = json.NewEncoder(os.Stdout)
+
+	var scanner = bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		var words = strings.Fields(scanner.Text())
+
+		if len(words) == 0 {
+			continue
+		}
+
+		if len(words) != 2 {
+			fmt.Fprintf(os.Stderr, "Line %v: expected two words, got %q\n", line, scanner.Text())
+			continue
+		}
+
+		if *jsonFlag {
+			if err := encoder.Encode(solve(words[0], words[1])); err != nil {
+				panic(err)
+			}
+		} else {
+			fmt.Printf("%v -> %v: %v\n", words[0], words[1], wordGraph.ShortestPath(words[0], words[1]))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading pairs: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// Answer a single query
+func solve(s1 string, s2 string) result {
+	var retval = result{From: s1, To: s2, Connected: wordGraph.AreTwoWordsConnected(s1, s2), Path: []string{}}
+
+	// Keep the empty path as [] rather than null, like the HTTP handler
+	if path := wordGraph.ShortestPath(s1, s2); len(path) > 0 {
+		retval.Path = path
+		retval.Steps = len(path) - 1
+	}
+
+	return retval
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/cheilman/go-wordladder/wordladder"
+)
+
+func TestSolvePairsJSON(t *testing.T) {
+	wordGraph = wordladder.NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "xyz"})
+
+	*jsonFlag = true
+	defer func() { *jsonFlag = false }()
+
+	// Capture stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout = os.Stdout
+	os.Stdout = w
+	solvePairs(strings.NewReader("cat dog\n\ncat xyz\n"))
+	os.Stdout = stdout
+	w.Close()
+
+	var tests = []result{
+		{From: "cat", To: "dog", Connected: true, Path: []string{"cat", "cot", "cog", "dog"}, Steps: 3},
+		{From: "cat", To: "xyz", Connected: false, Path: []string{}, Steps: 0},
+	}
+
+	var scanner = bufio.NewScanner(r)
+	for i, want := range tests {
+		if !scanner.Scan() {
+			t.Fatalf("only %v lines of output, expected %v", i, len(tests))
+		}
+
+		var got result
+		if err := json.Unmarshal(scanner.Bytes(), &got); err != nil {
+			t.Fatalf("line %v: %v", i+1, err)
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("line %v: got %+v, expected %+v", i+1, got, want)
+		}
+	}
+
+	if scanner.Scan() {
+		t.Errorf("unexpected extra output %q", scanner.Text())
+	}
+}
+
+func TestSolveEmptyPathIsNotNull(t *testing.T) {
+	wordGraph = wordladder.NewWordGraphFromWords([]string{"cat", "dog"})
+
+	var out, err = json.Marshal(solve("cat", "dog"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(string(out), `"path":[]`) {
+		t.Errorf("got %s, expected an empty path list", out)
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	var g = wordladder.NewWordGraphFromWords([]string{"cat", "cot", "dog", "a", "i", "fish"})
+
+	var out bytes.Buffer
+	printStats(&out, g)
+
+	var want = "Words: 6\n" +
+		"Forests: 4\n" +
+		"Distinct lengths: 3\n" +
+		"Edges: 2\n" +
+		"Length 1: 2 words, largest forest 2, 0 isolated\n" +
+		"Length 3: 3 words, largest forest 2, 1 isolated\n" +
+		"Length 4: 1 words, largest forest 1, 1 isolated\n"
+
+	if out.String() != want {
+		t.Errorf("got:\n%v\nexpected:\n%v", out.String(), want)
+	}
+}
+
+func TestPrintStatsDefaultDictionary(t *testing.T) {
+	var g = wordladder.NewWordGraph()
+	g.UseWildcardIndex = true
+	if _, err := g.LoadFromReader(wordladder.DefaultDictionary()); err != nil {
+		t.Fatal(err)
+	}
+	g.ExploreForests()
+
+	var out bytes.Buffer
+	printStats(&out, g)
+
+	var stats = g.Stats()
+	for _, line := range []string{
+		fmt.Sprintf("Words: %v\n", stats.TotalWords),
+		fmt.Sprintf("Forests: %v\n", stats.TotalForests),
+		fmt.Sprintf("Distinct lengths: %v\n", stats.DistinctLengths),
+	} {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("missing %q in:\n%v", line, out.String())
+		}
+	}
+
+	if got := strings.Count(out.String(), "\nLength "); got != stats.DistinctLengths {
+		t.Errorf("got %v per-length lines, expected %v", got, stats.DistinctLengths)
+	}
+}
+
+func TestLengthFlags(t *testing.T) {
+	var dict = "a\nat\ncat\ncot\ncold\ncord\nplant\nplane\nladders\n"
+
+	var tests = []struct {
+		name     string
+		min, max int
+		lengths  []int
+		words    int
+	}{
+		{"no limits", 0, 0, []int{1, 2, 3, 4, 5, 7}, 9},
+		{"three to six", 3, 6, []int{3, 4, 5}, 6},
+		{"just four", 4, 4, []int{4}, 2},
+		{"minimum only", 5, 0, []int{5, 7}, 3},
+		{"maximum only", 0, 2, []int{1, 2}, 2},
+	}
+
+	defer func() { *minLenFlag, *maxLenFlag = 0, 0 }()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			*minLenFlag, *maxLenFlag = test.min, test.max
+
+			var g = wordladder.NewWordGraph()
+			applyOptions(g)
+			g.Log = nil
+
+			if _, err := g.LoadFromReader(strings.NewReader(dict)); err != nil {
+				t.Fatal(err)
+			}
+			g.ExploreForests()
+
+			var lengths = []int{}
+			for length := range g.Graphs {
+				lengths = append(lengths, length)
+			}
+			sort.Ints(lengths)
+
+			if !reflect.DeepEqual(lengths, test.lengths) || g.GetTotalWords() != test.words {
+				t.Errorf("got %v words of lengths %v, expected %v of %v", g.GetTotalWords(), lengths, test.words, test.lengths)
+			}
+
+			if test.min <= 3 && (test.max == 0 || test.max >= 3) && g.ShortestPath("cat", "cot") == nil {
+				t.Errorf("cat -> cot should still be solvable")
+			}
+
+			if g.Contains("ladders") != (test.max == 0) {
+				t.Errorf("ladders loaded: %v, with maxlen %v", g.Contains("ladders"), test.max)
+			}
+		})
+	}
+}
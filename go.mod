@@ -0,0 +1,3 @@
+module github.com/cheilman/go-wordladder
+
+go 1.21
@@ -0,0 +1,54 @@
+package wordladder
+
+import (
+	"fmt"
+	"strings"
+)
+
+/**
+ * The letter that changed on one step of a ladder.
+ */
+type StepChange struct {
+	Index int  // position of the changed letter, counted in runes from 0
+	From  rune // the letter before the step
+	To    rune // the letter after it
+}
+
+// Describe each step of a ladder by the letter it changed, e.g. cat -> cot -> dot gives
+// (1, 'a', 'o') then (0, 'c', 'd').  One fewer change than there are words.
+// Errors (wrapping ErrBadStep) if any step doesn't change exactly one letter.
+func AnnotatePath(path []string) ([]StepChange, error) {
+	var retval = []StepChange{}
+
+	for i := 1; i < len(path); i++ {
+		if !areNeighbors(path[i-1], path[i]) {
+			return nil, fmt.Errorf("step %d, %q -> %q: %w", i, path[i-1], path[i], ErrBadStep)
+		}
+
+		var from, to = []rune(path[i-1]), []rune(path[i])
+
+		for j := range from {
+			if from[j] != to[j] {
+				retval = append(retval, StepChange{Index: j, From: from[j], To: to[j]})
+				break
+			}
+		}
+	}
+
+	return retval, nil
+}
+
+// A ladder ready for printing, e.g. "cat → cot → cog → dog (3 steps)".  "no path" if it's empty.
+func FormatLadder(path []string) string {
+	if len(path) == 0 {
+		return "no path"
+	}
+
+	var steps = len(path) - 1
+	var unit = "steps"
+	if steps == 1 {
+		unit = "step"
+	}
+
+	return fmt.Sprintf("%s (%d %s)", strings.Join(path, " → "), steps, unit)
+}
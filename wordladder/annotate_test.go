@@ -0,0 +1,57 @@
+package wordladder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestAnnotatePath(t *testing.T) {
+	var tests = []struct {
+		name string
+		path []string
+		want []StepChange
+		err  error
+	}{
+		{"cat cot dot", []string{"cat", "cot", "dot"}, []StepChange{{1, 'a', 'o'}, {0, 'c', 'd'}}, nil},
+		{"last letter", []string{"cat", "cab"}, []StepChange{{2, 't', 'b'}}, nil},
+		{"multibyte", []string{"cafe", "café"}, []StepChange{{3, 'e', 'é'}}, nil},
+		{"one word", []string{"cat"}, []StepChange{}, nil},
+		{"empty", []string{}, []StepChange{}, nil},
+		{"two letters", []string{"cat", "cot", "dog"}, nil, ErrBadStep},
+		{"no change", []string{"cat", "cat"}, nil, ErrBadStep},
+		{"different lengths", []string{"cat", "cats"}, nil, ErrBadStep},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got, err = AnnotatePath(test.path)
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("got error %v, expected %v", err, test.err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFormatLadder(t *testing.T) {
+	var tests = []struct {
+		path []string
+		want string
+	}{
+		{[]string{"cat", "cot", "cog", "dog"}, "cat → cot → cog → dog (3 steps)"},
+		{[]string{"cat", "cot"}, "cat → cot (1 step)"},
+		{[]string{"cat"}, "cat (0 steps)"},
+		{nil, "no path"},
+	}
+
+	for _, test := range tests {
+		if got := FormatLadder(test.path); got != test.want {
+			t.Errorf("FormatLadder(%v): got %q, expected %q", test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package wordladder
+
+import (
+	"math/rand"
+)
+
+// Estimate how often each word in a forest lies on shortest paths between other words (betweenness
+// centrality), to find the hub words.  Rather than searching from every word, it runs Brandes'
+// algorithm from samples random words and scales the result up, so it's approximate by design.
+// Every word in the forest gets a score; the same seed on the same graph gives the same scores.
+func (g *WordGraphOfSameLength) ApproxCentrality(tag int, samples int, rng *rand.Rand) map[string]float64 {
+	var words = g.WordsInForest(tag)
+	var retval = make(map[string]float64, len(words))
+
+	for _, word := range words {
+		retval[word] = 0
+	}
+
+	if len(words) == 0 || samples <= 0 {
+		return retval
+	}
+
+	for i := 0; i < samples; i++ {
+		var source = words[rng.Intn(len(words))]
+
+		// BFS from the source, counting the shortest paths to each word and who they come through
+		var order = []string{}
+		var parents = make(map[string][]string)
+		var paths = map[string]float64{source: 1}
+		var depth = map[string]int{source: 0}
+		var layer = []string{source}
+
+		for len(layer) > 0 {
+			var nextLayer = []string{}
+
+			for _, word := range layer {
+				order = append(order, word)
+
+				for _, neighborWord := range g.neighborsOf(g.node(word)) {
+					var d, seen = depth[*neighborWord]
+
+					if !seen {
+						d = depth[word] + 1
+						depth[*neighborWord] = d
+						nextLayer = append(nextLayer, *neighborWord)
+					}
+
+					if d == depth[word]+1 {
+						paths[*neighborWord] += paths[word]
+						parents[*neighborWord] = append(parents[*neighborWord], word)
+					}
+				}
+			}
+
+			layer = nextLayer
+		}
+
+		// Then work back from the furthest words, crediting each parent with its share of the paths
+		var dependency = make(map[string]float64)
+
+		for j := len(order) - 1; j >= 0; j-- {
+			var word = order[j]
+
+			for _, parent := range parents[word] {
+				dependency[parent] += paths[parent] / paths[word] * (1 + dependency[word])
+			}
+
+			if word != source {
+				retval[word] += dependency[word]
+			}
+		}
+	}
+
+	// Scale up as if every word had been a source
+	var scale = float64(len(words)) / float64(samples)
+	for word := range retval {
+		retval[word] *= scale
+	}
+
+	return retval
+}
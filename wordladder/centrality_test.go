@@ -0,0 +1,57 @@
+package wordladder
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestApproxCentrality(t *testing.T) {
+	// A star: bat, cot and cab each change one letter of cat, and are two changes from each other
+	var star = NewWordGraphFromWords([]string{"cat", "bat", "cot", "cab"}).Graphs[3]
+	var chain = NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "dig"}).Graphs[3]
+
+	var tests = []struct {
+		name    string
+		g       *WordGraphOfSameLength
+		samples int
+		hub     string
+	}{
+		{"star", star, 20, "cat"},
+		{"chain", chain, 50, "cog"}, // The middle of the chain
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var tag = test.g.node(test.hub).ForestTag
+			var scores = test.g.ApproxCentrality(tag, test.samples, rand.New(rand.NewSource(1)))
+
+			if len(scores) != test.g.GetTotalWords() {
+				t.Errorf("got scores for %v words, expected %v", len(scores), test.g.GetTotalWords())
+			}
+
+			for word, score := range scores {
+				if word != test.hub && score >= scores[test.hub] {
+					t.Errorf("%v scored %v, not below the hub %v's %v", word, score, test.hub, scores[test.hub])
+				}
+			}
+
+			// The same seed again gives the same scores
+			if again := test.g.ApproxCentrality(tag, test.samples, rand.New(rand.NewSource(1))); !reflect.DeepEqual(again, scores) {
+				t.Errorf("got %v then %v from the same seed", scores, again)
+			}
+		})
+	}
+
+	// The star's leaves are never between anything
+	var scores = star.ApproxCentrality(star.node("cat").ForestTag, 20, rand.New(rand.NewSource(2)))
+	for _, leaf := range []string{"bat", "cot", "cab"} {
+		if scores[leaf] != 0 {
+			t.Errorf("%v scored %v, expected 0", leaf, scores[leaf])
+		}
+	}
+
+	if got := star.ApproxCentrality(999, 10, rand.New(rand.NewSource(1))); len(got) != 0 {
+		t.Errorf("got %v for a forest that doesn't exist", got)
+	}
+}
@@ -0,0 +1,8 @@
+package wordladder
+
+// The shortest ladder from s1 to s2 in each of two graphs, say built from different dictionaries, to see
+// whether a ladder in one has a counterpart in the other.  Either is nil if that graph has no ladder.
+// Graphs don't share any state, so as many as you like can be loaded and searched side by side.
+func DiffLadder(a *WordGraph, b *WordGraph, s1 string, s2 string) (inA []string, inB []string) {
+	return a.ShortestPath(s1, s2), b.ShortestPath(s1, s2)
+}
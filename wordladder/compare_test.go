@@ -0,0 +1,39 @@
+package wordladder
+
+import (
+	"testing"
+)
+
+func TestDiffLadder(t *testing.T) {
+	// The same words, less cot and cog: cat can't reach dog in the smaller dictionary
+	var full = NewWordGraphFromWords(fixtureCatDog)
+	var small = NewWordGraphFromWords([]string{"bat", "cat", "dog", "hat"})
+
+	var tests = []struct {
+		s1, s2   string
+		inA, inB []string
+	}{
+		{"cat", "cog", []string{"cat", "cot", "cog"}, nil},
+		{"cat", "bat", []string{"cat", "bat"}, []string{"cat", "bat"}},
+		{"cat", "cut", nil, nil},
+	}
+
+	for _, test := range tests {
+		inA, inB := DiffLadder(full, small, test.s1, test.s2)
+		if !samePath(inA, test.inA) || !samePath(inB, test.inB) {
+			t.Errorf("%v -> %v: got %v and %v, expected %v and %v", test.s1, test.s2, inA, inB, test.inA, test.inB)
+		}
+
+		// And the other way round
+		inB, inA = DiffLadder(small, full, test.s1, test.s2)
+		if !samePath(inA, test.inA) || !samePath(inB, test.inB) {
+			t.Errorf("%v -> %v swapped: got %v and %v, expected %v and %v", test.s1, test.s2, inB, inA, test.inB, test.inA)
+		}
+	}
+
+	// The ladder that only one graph has is a real ladder there and not in the other
+	inA, inB := DiffLadder(full, small, "cat", "dog")
+	if valid, at := full.IsValidLadder(inA); !valid || inB != nil {
+		t.Errorf("got %v (invalid at %v) and %v, expected a ladder only in the first graph", inA, at, inB)
+	}
+}
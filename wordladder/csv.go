@@ -0,0 +1,32 @@
+package wordladder
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// Write every word as a CSV row of word, length, forest tag, and number of neighbors, after a header row.
+// Rows are sorted by length and then word, so the output is repeatable.
+func (g *WordGraph) WriteCSV(w io.Writer) error {
+	g.exploreIfDirty()
+
+	var out = csv.NewWriter(w)
+
+	out.Write([]string{"word", "length", "forest", "degree"})
+
+	g.EachWord(func(word string) {
+		var node = g.node(word)
+
+		out.Write([]string{
+			word,
+			strconv.Itoa(wordLength(word)),
+			strconv.Itoa(node.ForestTag),
+			strconv.Itoa(len(g.Graphs[wordLength(word)].neighborsOf(node))),
+		})
+	})
+
+	// Write errors stick, so this reports the first one
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,63 @@
+package wordladder
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var out bytes.Buffer
+	if err := g.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want = [][]string{
+		{"word", "length", "forest", "degree"},
+		{"a", "1", "1", "1"},
+		{"i", "1", "1", "1"},
+		{"at", "2", "1", "1"},
+		{"it", "2", "1", "1"},
+		{"cat", "3", "1", "1"},
+		{"cot", "3", "1", "1"},
+		{"dog", "3", "2", "0"},
+		{"cold", "4", "1", "1"},
+		{"cord", "4", "1", "1"},
+		{"fish", "4", "2", "0"},
+	}
+
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("got %v, expected %v", rows, want)
+	}
+}
+
+// A writer that fails once it's been given limit bytes
+type failingWriter struct {
+	limit int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		return 0, errors.New("disk full")
+	}
+
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func TestWriteCSVError(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	if err := g.WriteCSV(&failingWriter{limit: 10}); err == nil {
+		t.Errorf("expected the write error")
+	}
+}
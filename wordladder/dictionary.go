@@ -0,0 +1,62 @@
+package wordladder
+
+import (
+	"bufio"
+	"compress/gzip"
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+)
+
+//go:embed words.txt
+var defaultWords string
+
+// A built-in list of a few thousand common English words, for when there's no system dictionary
+func DefaultDictionary() io.Reader {
+	return strings.NewReader(defaultWords)
+}
+
+// Open a word list for LoadFromReader, unzipping it on the way if it's gzipped.  Gzipped files are
+// spotted by their contents, not their name.  Close it when done.
+func OpenDictionary(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var br = bufio.NewReader(f)
+
+	// Gzip streams start with these two bytes, and no word list does
+	if magic, err := br.Peek(2); err != nil || magic[0] != 0x1f || magic[1] != 0x8b {
+		return &dictionaryFile{Reader: br, files: []io.Closer{f}}, nil
+	}
+
+	zr, err := gzip.NewReader(br)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &dictionaryFile{Reader: zr, files: []io.Closer{zr, f}}, nil
+}
+
+/**
+ * An open word list, which may be several readers deep.
+ */
+type dictionaryFile struct {
+	io.Reader
+	files []io.Closer // closed in order
+}
+
+func (d *dictionaryFile) Close() error {
+	var retval error
+
+	for _, f := range d.files {
+		if err := f.Close(); err != nil && retval == nil {
+			retval = err
+		}
+	}
+
+	return retval
+}
@@ -0,0 +1,79 @@
+package wordladder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Gzip some text
+func gzipped(t *testing.T, text string) []byte {
+	var buf bytes.Buffer
+	var zw = gzip.NewWriter(&buf)
+
+	if _, err := zw.Write([]byte(text)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestOpenDictionary(t *testing.T) {
+	var list = "cat\ncot\ncog\ndog\nNot\n"
+	var dir = t.TempDir()
+
+	var tests = []struct {
+		name     string
+		file     string
+		contents []byte
+		ok       bool
+	}{
+		{"plain", "words.txt", []byte(list), true},
+		{"gzipped", "words.txt.gz", gzipped(t, list), true},
+		{"gzipped without the name", "words", gzipped(t, list), true},
+		{"gz name but plain", "plain.gz", []byte(list), true}, // It's the contents that count
+		{"broken gzip", "broken.gz", []byte{0x1f, 0x8b, 0, 1, 2}, false},
+		{"missing", "missing.txt", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var path = filepath.Join(dir, test.file)
+			if test.contents != nil {
+				if err := os.WriteFile(path, test.contents, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			f, err := OpenDictionary(path)
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, expected ok=%v", err, test.ok)
+			}
+
+			if !test.ok {
+				return
+			}
+
+			var g = NewWordGraph()
+			added, err := g.LoadFromReader(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := g.Graphs[3].Words(); added != 4 || !reflect.DeepEqual(got, []string{"cat", "cog", "cot", "dog"}) {
+				t.Errorf("loaded %v words: %v", added, got)
+			}
+
+			if err := f.Close(); err != nil {
+				t.Errorf("Close: %v", err)
+			}
+		})
+	}
+}
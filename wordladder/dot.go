@@ -0,0 +1,56 @@
+package wordladder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Quote a word as a Graphviz DOT ID
+func dotQuote(word string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(word) + `"`
+}
+
+// Write one forest as an undirected Graphviz DOT graph: a node per word and an edge per neighbor pair.
+// Each edge is written once, and everything is sorted so the output is repeatable.
+// A Directed graph is written as a digraph instead, with every edge in the direction it goes.
+func (g *WordGraphOfSameLength) WriteForestDOT(w io.Writer, tag int) error {
+	var out = bufio.NewWriter(w)
+	var words = g.WordsInForest(tag)
+
+	var kind, edge = "graph", "--"
+	if g.Directed {
+		kind, edge = "digraph", "->"
+	}
+
+	fmt.Fprintf(out, "%v forest_%v_%v {\n", kind, g.WordLength, tag)
+
+	for _, word := range words {
+		fmt.Fprintf(out, "\t%v;\n", dotQuote(word))
+	}
+
+	for _, word := range words {
+		var neighbors = []string{}
+
+		for _, neighborWord := range g.neighborsOf(g.node(word)) {
+			// Only from the alphabetically first end, so we don't write it twice.  Directed edges
+			// are only listed from the end they start at anyway.
+			if g.Directed || word < *neighborWord {
+				neighbors = append(neighbors, *neighborWord)
+			}
+		}
+
+		sort.Strings(neighbors)
+
+		for _, neighborWord := range neighbors {
+			fmt.Fprintf(out, "\t%v %v %v;\n", dotQuote(word), edge, dotQuote(neighborWord))
+		}
+	}
+
+	fmt.Fprintf(out, "}\n")
+
+	// The buffered writer holds on to the first write error, if there was one
+	return out.Flush()
+}
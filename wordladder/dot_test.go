@@ -0,0 +1,104 @@
+package wordladder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteForestDOT(t *testing.T) {
+	var tests = []struct {
+		name     string
+		directed bool
+		want     string
+	}{
+		{"undirected", false, "graph forest_3_1 {\n" +
+			"\t\"cat\";\n\t\"cot\";\n\t\"zzz\";\n" +
+			"\t\"cat\" -- \"cot\";\n\t\"cat\" -- \"zzz\";\n" +
+			"}\n"},
+		{"directed", true, "digraph forest_3_1 {\n" +
+			"\t\"cat\";\n\t\"cot\";\n\t\"zzz\";\n" +
+			"\t\"cat\" -> \"cot\";\n\t\"cot\" -> \"cat\";\n\t\"zzz\" -> \"cat\";\n" +
+			"}\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphOfSameLength(3)
+			g.Directed = test.directed
+			for _, word := range []string{"cat", "cot", "zzz"} {
+				g.AddWord(word)
+			}
+			g.ExploreAllForests()
+
+			if err := g.Link("zzz", "cat"); err != nil {
+				t.Fatal(err)
+			}
+
+			var out bytes.Buffer
+			if err := g.WriteForestDOT(&out, g.WordGraph["cat"].ForestTag); err != nil {
+				t.Fatal(err)
+			}
+
+			if out.String() != test.want {
+				t.Errorf("got:\n%v\nexpected:\n%v", out.String(), test.want)
+			}
+		})
+	}
+}
+
+func TestDotQuote(t *testing.T) {
+	var tests = []struct {
+		word string
+		want string
+	}{
+		{"cat", `"cat"`},
+		{`a"b`, `"a\"b"`},
+		{`a\b`, `"a\\b"`},
+		{"café", `"café"`},
+	}
+
+	for _, test := range tests {
+		if got := dotQuote(test.word); got != test.want {
+			t.Errorf("dotQuote(%v): got %v, expected %v", test.word, got, test.want)
+		}
+	}
+}
+
+func TestWriteForestDOTEachForest(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureColdWarm).Graphs[4]
+
+	var tests = []struct {
+		word  string
+		nodes int
+		edges int
+	}{
+		{"cold", 7, 8}, // cold-cord, and the two squares card-cord-word-ward and ward-word-worm-warm
+		{"fish", 2, 1},
+	}
+
+	for _, test := range tests {
+		var out bytes.Buffer
+		if err := g.WriteForestDOT(&out, g.WordGraph[test.word].ForestTag); err != nil {
+			t.Fatal(err)
+		}
+
+		var nodes, edges = 0, 0
+		for _, line := range strings.Split(out.String(), "\n") {
+			switch {
+			case strings.Contains(line, " -- "):
+				edges++
+			case strings.HasPrefix(line, "\t"):
+				nodes++
+			}
+		}
+
+		if nodes != test.nodes || edges != test.edges {
+			t.Errorf("%v's forest: got %v nodes and %v edges, expected %v and %v", test.word, nodes, edges, test.nodes, test.edges)
+		}
+
+		if !strings.Contains(out.String(), "\t\""+test.word+"\";\n") {
+			t.Errorf("%v's forest is missing its node:\n%v", test.word, out.String())
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package wordladder
+
+import (
+	"slices"
+)
+
+// Words one letter shorter than word, made by deleting each letter in turn.  May contain duplicates
+// (e.g. "book" -> "bok" twice).
+func deletions(word string) []string {
+	var letters = []rune(word)
+	var retval = make([]string, len(letters))
+
+	for i := range letters {
+		retval[i] = string(letters[:i]) + string(letters[i+1:])
+	}
+
+	return retval
+}
+
+// Bucket every word under each of its one-letter deletions, so we can find which words are one
+// insertion away from a shorter word
+func (g *WordGraphOfSameLength) buildDeletionIndex() {
+	g.deletionIndex = make(map[string][]*string)
+
+	g.eachNode(func(v *WordNode) {
+		var seen = make(map[string]bool)
+
+		for _, shorter := range deletions(v.Word) {
+			if !seen[shorter] {
+				seen[shorter] = true
+				g.deletionIndex[shorter] = append(g.deletionIndex[shorter], &v.Word)
+			}
+		}
+	})
+}
+
+// Words one substitution, insertion, or deletion away from word
+func (g *WordGraph) editNeighbors(word string) []string {
+	var retval = []string{}
+
+	// Substitutions are already worked out
+	if node := g.node(word); node != nil {
+		for _, neighborWord := range g.Graphs[wordLength(word)].neighborsOf(node) {
+			retval = append(retval, *neighborWord)
+		}
+	}
+
+	// Deletions are in the subgraph one shorter
+	if shorter := g.Graphs[wordLength(word)-1]; shorter != nil {
+		for _, candidate := range deletions(word) {
+			if shorter.node(candidate) != nil {
+				retval = append(retval, candidate)
+			}
+		}
+	}
+
+	// Insertions are in the subgraph one longer
+	if longer := g.Graphs[wordLength(word)+1]; longer != nil {
+		if longer.deletionIndex == nil {
+			longer.buildDeletionIndex()
+		}
+
+		for _, candidate := range longer.deletionIndex[word] {
+			retval = append(retval, *candidate)
+		}
+	}
+
+	return retval
+}
+
+// Return a shortest path from s1 to s2 where each step substitutes, inserts, or deletes one letter
+// (e.g. "cat" -> "cart" -> "card").  Nil if no path exists.
+// Forest tags only cover a single length, so this always does a full BFS across the subgraphs.
+func (g *WordGraph) ShortestPathEdit(s1 string, s2 string) []string {
+	g.exploreIfDirty()
+
+	s1, s2 = g.fold(s1), g.fold(s2)
+
+	if g.node(s1) == nil || g.node(s2) == nil {
+		return nil
+	}
+
+	// Like ShortestPath, search backwards (s2 -> s1) so following parents from s1 walks towards s2.
+	// Insertions and deletions go both ways, but Directed substitutions and Links don't, so then
+	// search forwards and reverse the path.
+	var root, goal = s2, s1
+	if g.Directed {
+		root, goal = s1, s2
+	}
+
+	var parents = map[string]string{root: root}
+	var layer = []string{root}
+
+	for len(layer) > 0 {
+		var nextLayer = []string{}
+
+		for _, word := range layer {
+			if word == goal {
+				// Build the path back up
+				var retval = []string{}
+
+				for cur := goal; ; cur = parents[cur] {
+					retval = append(retval, cur)
+
+					if cur == root {
+						break
+					}
+				}
+
+				if g.Directed {
+					slices.Reverse(retval)
+				}
+
+				return retval
+			}
+
+			for _, neighborWord := range g.editNeighbors(word) {
+				if _, seen := parents[neighborWord]; !seen {
+					parents[neighborWord] = word
+					nextLayer = append(nextLayer, neighborWord)
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package wordladder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShortestPathEdit(t *testing.T) {
+	var g = NewWordGraphFromWords([]string{"a", "at", "cat", "cart", "card", "cold", "dog", "scat"})
+
+	var tests = []struct {
+		s1, s2 string
+		path   []string
+	}{
+		{"cat", "card", []string{"cat", "cart", "card"}},
+		{"card", "cat", []string{"card", "cart", "cat"}},
+		{"a", "scat", []string{"a", "at", "cat", "scat"}},
+		{"cat", "cat", []string{"cat"}},
+		{"cat", "dog", nil},
+		{"cat", "cold", nil},
+		{"cat", "cut", nil},
+	}
+
+	for _, test := range tests {
+		if got := g.ShortestPathEdit(test.s1, test.s2); !samePath(got, test.path) {
+			t.Errorf("ShortestPathEdit(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.path)
+		}
+	}
+}
+
+func TestShortestPathEditDirected(t *testing.T) {
+	// zzz only leads to cat, and cat can't get back
+	var g = NewWordGraph()
+	g.Directed = true
+	for _, word := range []string{"at", "cat", "cot", "zzz"} {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+	if err := g.Link("zzz", "cat"); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		s1, s2 string
+		path   []string
+	}{
+		{"zzz", "cot", []string{"zzz", "cat", "cot"}},
+		{"cot", "zzz", nil},
+		{"zzz", "at", []string{"zzz", "cat", "at"}}, // Deleting a letter goes either way
+		{"at", "cot", []string{"at", "cat", "cot"}},
+		{"cat", "zzz", nil},
+	}
+
+	for _, test := range tests {
+		var got = g.ShortestPathEdit(test.s1, test.s2)
+		if !samePath(got, test.path) {
+			t.Errorf("ShortestPathEdit(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.path)
+		}
+
+		if test.path == nil && wordLength(test.s1) == wordLength(test.s2) && g.ShortestPath(test.s1, test.s2) != nil {
+			t.Errorf("ShortestPath(%v, %v) should agree there's no path", test.s1, test.s2)
+		}
+	}
+}
+
+func TestDeletions(t *testing.T) {
+	var tests = []struct {
+		word string
+		want []string
+	}{
+		{"cat", []string{"at", "ct", "ca"}},
+		{"a", []string{""}},
+		{"", []string{}},
+	}
+
+	for _, test := range tests {
+		if got := deletions(test.word); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("deletions(%q): got %q, expected %q", test.word, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package wordladder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The two words have different lengths, so no substitution ladder can join them
+var ErrLengthMismatch = errors.New("words are different lengths")
+
+// Both words are in the dictionary, but in different forests
+var ErrNoPath = errors.New("no path between words")
+
+// Two consecutive words in a ladder aren't exactly one letter apart
+var ErrBadStep = errors.New("step doesn't change exactly one letter")
+
+// A saved graph was written by a different version of this package (see FormatVersion)
+var ErrUnsupportedVersion = errors.New("unsupported graph file version")
+
+// A saved graph was built from a different dictionary than the one expected
+var ErrStaleGraph = errors.New("graph was built from a different dictionary")
+
+// A word isn't in the dictionary
+type ErrWordNotFound struct {
+	Word string // the missing word
+}
+
+func (e ErrWordNotFound) Error() string {
+	return fmt.Sprintf("word not found: %q", e.Word)
+}
+
+// A word is already in the dictionary, so it can't be put in again
+type ErrWordExists struct {
+	Word string // the word that's already there
+}
+
+func (e ErrWordExists) Error() string {
+	return fmt.Sprintf("word already present: %q", e.Word)
+}
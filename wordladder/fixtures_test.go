@@ -0,0 +1,54 @@
+package wordladder
+
+import (
+	"testing"
+)
+
+// Small word sets for building graphs inline with NewWordGraphFromWords, shared across the tests.
+
+// Three-letter words forming a single forest, with cat -> dog taking three steps
+// (cat, cot, cog, dog) and a few side branches.
+var fixtureCatDog = []string{"bat", "cat", "cog", "cot", "dog", "dot", "hat", "hot"}
+
+// Four-letter words forming two forests: cold -> warm, which takes four steps (by way of
+// either card or word), and the unconnected pair fish/dish.
+var fixtureColdWarm = []string{"card", "cold", "cord", "dish", "fish", "ward", "warm", "word", "worm"}
+
+// Words of several lengths, so tests can check that lengths stay apart.
+var fixtureMixed = []string{"a", "i", "at", "it", "cat", "cot", "dog", "cold", "cord", "fish"}
+
+func TestNewWordGraphFromWords(t *testing.T) {
+	var tests = []struct {
+		name    string
+		words   []string
+		s1, s2  string
+		steps   int
+		forests int
+	}{
+		{"cat-dog", fixtureCatDog, "cat", "dog", 3, 1},
+		{"cold-warm", fixtureColdWarm, "cold", "warm", 4, 2},
+		{"mixed", fixtureMixed, "cold", "cord", 1, 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words)
+
+			if g.IsDirty() {
+				t.Errorf("graph should already be explored")
+			}
+
+			if got := g.GetTotalWords(); got != len(test.words) {
+				t.Errorf("got %v words, expected %v", got, len(test.words))
+			}
+
+			if got, ok := g.LadderLength(test.s1, test.s2); !ok || got != test.steps {
+				t.Errorf("%v -> %v took %v steps, expected %v", test.s1, test.s2, got, test.steps)
+			}
+
+			if got := g.GetTotalForests(); got != test.forests {
+				t.Errorf("got %v forests, expected %v", got, test.forests)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package wordladder
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// All the words with the given forest tag, sorted alphabetically.  Empty if there are none.
+func (g *WordGraphOfSameLength) WordsInForest(tag int) []string {
+	var retval = []string{}
+
+	g.eachNode(func(v *WordNode) {
+		if v.ForestTag == tag {
+			retval = append(retval, v.Word)
+		}
+	})
+
+	sort.Strings(retval)
+
+	return retval
+}
+
+// All the words in the same forest as word (including word), sorted alphabetically.
+// Empty if the word isn't in the graph.
+func (g *WordGraph) WordsInForestOf(word string) []string {
+	g.exploreIfDirty()
+
+	word = g.fold(word)
+
+	var node = g.node(word)
+	if node == nil {
+		return []string{}
+	}
+
+	return g.Graphs[wordLength(word)].WordsInForest(node.ForestTag)
+}
+
+// Map of forest tag to number of words, counted straight from the assigned forest tags in one pass
+// over the words, without following any neighbors.  Words that haven't been explored yet are counted
+// under tag 0.
+func (g *WordGraphOfSameLength) ForestSizes() map[int]int {
+	var retval = make(map[int]int)
+
+	g.eachNode(func(v *WordNode) {
+		retval[v.ForestTag]++
+	})
+
+	return retval
+}
+
+// Map of forest tag to number of words, for words of the given length.
+func (g *WordGraph) ForestSizeHistogram(length int) map[int]int {
+	g.exploreIfDirty()
+
+	var subgraph = g.Graphs[length]
+	if subgraph == nil {
+		return map[int]int{}
+	}
+
+	return subgraph.ForestSizes()
+}
+
+// ForestSizes for every word length: length to forest tag to number of words
+func (g *WordGraph) ForestSizesByLength() map[int]map[int]int {
+	g.exploreIfDirty()
+
+	var retval = make(map[int]map[int]int, len(g.Graphs))
+
+	for length, subgraph := range g.Graphs {
+		retval[length] = subgraph.ForestSizes()
+	}
+
+	return retval
+}
+
+// The forest with the most words of the given length, and how many words it has.
+// Ties go to the lowest tag.  (0, 0) if there are no words of that length.
+func (g *WordGraph) LargestForest(length int) (tag int, size int) {
+	for t, count := range g.ForestSizeHistogram(length) {
+		if count > size || (count == size && t < tag) {
+			tag, size = t, count
+		}
+	}
+
+	return tag, size
+}
+
+// Steps from word to every word reachable from it, by BFS
+func (g *WordGraphOfSameLength) stepsFrom(word string) map[string]int {
+	return g.WordsWithinSteps(word, math.MaxInt)
+}
+
+// Every word reachable from word in at most n steps, mapped to the fewest steps it takes.
+// The word itself maps to 0.  Empty if the word isn't in the graph (or n is negative).
+func (g *WordGraphOfSameLength) WordsWithinSteps(word string, n int) map[string]int {
+	if g.node(word) == nil || n < 0 {
+		return map[string]int{}
+	}
+
+	var retval = map[string]int{word: 0}
+	var layer = []string{word}
+
+	for steps := 1; steps <= n && len(layer) > 0; steps++ {
+		var nextLayer = []string{}
+
+		for _, w := range layer {
+			for _, neighborWord := range g.neighborsOf(g.node(w)) {
+				if _, seen := retval[*neighborWord]; !seen {
+					retval[*neighborWord] = steps
+					nextLayer = append(nextLayer, *neighborWord)
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return retval
+}
+
+// The hardest ladder in a forest: the pair of words whose shortest path is longest, and its steps.
+// Runs a BFS from every word in the forest, so it's O(n * edges) -- slow on big forests.
+// See ForestDiameterContext to be able to give up part way.
+func (g *WordGraphOfSameLength) ForestDiameter(tag int) (s1, s2 string, steps int) {
+	s1, s2, steps, _ = g.ForestDiameterContext(context.Background(), tag)
+	return s1, s2, steps
+}
+
+// Like ForestDiameter, but checks ctx between searches and returns its error if it's done
+func (g *WordGraphOfSameLength) ForestDiameterContext(ctx context.Context, tag int) (s1, s2 string, steps int, err error) {
+	var words = g.WordsInForest(tag)
+
+	if len(words) > 0 {
+		s1, s2 = words[0], words[0]
+	}
+
+	for _, start := range words {
+		if err := ctx.Err(); err != nil {
+			return "", "", 0, err
+		}
+
+		for word, d := range g.stepsFrom(start) {
+			// Ties go to the alphabetically first far end, so the answer is repeatable
+			if d > steps || (d == steps && start == s1 && word < s2) {
+				s1, s2, steps = start, word, d
+			}
+		}
+	}
+
+	return s1, s2, steps, nil
+}
+
+// How many neighbors a word has.  False if the word isn't in the graph.
+func (g *WordGraph) Degree(word string) (int, bool) {
+	g.exploreIfDirty()
+
+	word = g.fold(word)
+
+	var node = g.node(word)
+	if node == nil {
+		return 0, false
+	}
+
+	return len(g.Graphs[wordLength(word)].neighborsOf(node)), true
+}
+
+// The n words with the most neighbors, most first.  Ties go alphabetically.
+func (g *WordGraphOfSameLength) MostConnectedWords(n int) []string {
+	var retval = make([]string, 0, g.GetTotalWords())
+	var degrees = make(map[string]int, g.GetTotalWords())
+
+	g.eachNode(func(v *WordNode) {
+		retval = append(retval, v.Word)
+		degrees[v.Word] = len(g.neighborsOf(v))
+	})
+
+	sort.Slice(retval, func(i, j int) bool {
+		var di, dj = degrees[retval[i]], degrees[retval[j]]
+		if di != dj {
+			return di > dj
+		}
+
+		return retval[i] < retval[j]
+	})
+
+	if n < 0 {
+		n = 0
+	}
+
+	if n < len(retval) {
+		retval = retval[:n]
+	}
+
+	return retval
+}
+
+// How many neighbors each end of a would-be ladder has, to tell why ShortestPath found nothing: a word
+// with no neighbors at all is a dead end, whereas otherwise the two are just in separate forests.
+// -1 for a word that isn't in the graph.
+func (g *WordGraphOfSameLength) EndpointDegrees(s1 string, s2 string) (d1, d2 int) {
+	var degree = func(word string) int {
+		var node = g.node(word)
+		if node == nil {
+			return -1
+		}
+
+		return len(g.neighborsOf(node))
+	}
+
+	return degree(s1), degree(s2)
+}
+
+// Words with no neighbors at all (each one is a forest of its own), sorted alphabetically
+func (g *WordGraphOfSameLength) IsolatedWords() []string {
+	var retval = []string{}
+
+	g.eachNode(func(v *WordNode) {
+		if len(g.neighborsOf(v)) == 0 {
+			retval = append(retval, v.Word)
+		}
+	})
+
+	sort.Strings(retval)
+
+	return retval
+}
+
+// Isolated words grouped by length.  Lengths with no isolated words are left out.
+func (g *WordGraph) IsolatedWords() map[int][]string {
+	g.exploreIfDirty()
+
+	var retval = make(map[int][]string)
+
+	for l, subgraph := range g.Graphs {
+		if isolated := subgraph.IsolatedWords(); len(isolated) > 0 {
+			retval[l] = isolated
+		}
+	}
+
+	return retval
+}
+
+// The words in a forest whose removal would split it in two (articulation points), sorted alphabetically.
+// Uses Tarjan's algorithm: a depth-first search tracking the earliest word each subtree can reach
+// without going back through its parent.  Directed graphs are treated as if every edge went both ways.
+func (g *WordGraphOfSameLength) ArticulationWords(tag int) []string {
+	var words = g.WordsInForest(tag)
+	var retval = []string{}
+
+	if len(words) == 0 {
+		return retval
+	}
+
+	// Edges either way, in case the graph is directed
+	var incoming map[string][]string
+	if g.Directed {
+		incoming = g.incomingEdges()
+	}
+
+	var adjacent = func(word string) []string {
+		var retval = []string{}
+		for _, neighborWord := range g.neighborsOf(g.node(word)) {
+			retval = append(retval, *neighborWord)
+		}
+		return append(retval, incoming[word]...)
+	}
+
+	var order = make(map[string]int) // when each word was first visited
+	var low = make(map[string]int)   // earliest visit reachable from its subtree
+	var cut = make(map[string]bool)
+
+	var visit func(word string, parent string)
+	visit = func(word string, parent string) {
+		order[word] = len(order) + 1
+		low[word] = order[word]
+		var children = 0
+
+		for _, next := range adjacent(word) {
+			if next == parent {
+				continue
+			}
+
+			if order[next] > 0 {
+				// Already seen, a back edge
+				if order[next] < low[word] {
+					low[word] = order[next]
+				}
+				continue
+			}
+
+			children++
+			visit(next, word)
+
+			if low[next] < low[word] {
+				low[word] = low[next]
+			}
+
+			// The subtree under next can't get above word without it
+			if parent != "" && low[next] >= order[word] {
+				cut[word] = true
+			}
+		}
+
+		// The root is only a cut if it holds separate subtrees together
+		if parent == "" && children > 1 {
+			cut[word] = true
+		}
+	}
+	visit(words[0], "")
+
+	for _, word := range words {
+		if cut[word] {
+			retval = append(retval, word)
+		}
+	}
+
+	return retval
+}
@@ -0,0 +1,302 @@
+package wordladder
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestWordsInForest(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureColdWarm)
+	var _, coldTag, _ = g.ForestTag("cold")
+	var _, fishTag, _ = g.ForestTag("fish")
+
+	var tests = []struct {
+		tag  int
+		want []string
+	}{
+		{coldTag, []string{"card", "cold", "cord", "ward", "warm", "word", "worm"}},
+		{fishTag, []string{"dish", "fish"}},
+		{999, []string{}},
+	}
+
+	for _, test := range tests {
+		if got := g.Graphs[4].WordsInForest(test.tag); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("WordsInForest(%v): got %v, expected %v", test.tag, got, test.want)
+		}
+	}
+}
+
+func TestWordsInForestOf(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		word string
+		want []string
+	}{
+		{"cat", []string{"cat", "cot"}},
+		{"a", []string{"a", "i"}},
+		{"fish", []string{"fish"}},
+		{"cut", []string{}},
+	}
+
+	for _, test := range tests {
+		if got := g.WordsInForestOf(test.word); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("WordsInForestOf(%v): got %v, expected %v", test.word, got, test.want)
+		}
+	}
+}
+
+func TestLargestForest(t *testing.T) {
+	// Forests of 4 (cat, cot, cog, dog), 2 (pin, pit) and 1 (xyz) three-letter words
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "pin", "pit", "xyz", "cold"})
+	var _, catTag, _ = g.ForestTag("cat")
+	var _, pinTag, _ = g.ForestTag("pin")
+	var _, xyzTag, _ = g.ForestTag("xyz")
+	var _, coldTag, _ = g.ForestTag("cold")
+
+	var tests = []struct {
+		length    int
+		histogram map[int]int
+		tag, size int
+	}{
+		{3, map[int]int{catTag: 4, pinTag: 2, xyzTag: 1}, catTag, 4},
+		{4, map[int]int{coldTag: 1}, coldTag, 1},
+		{5, map[int]int{}, 0, 0},
+	}
+
+	for _, test := range tests {
+		if got := g.ForestSizeHistogram(test.length); !reflect.DeepEqual(got, test.histogram) {
+			t.Errorf("ForestSizeHistogram(%v): got %v, expected %v", test.length, got, test.histogram)
+		}
+
+		if tag, size := g.LargestForest(test.length); tag != test.tag || size != test.size {
+			t.Errorf("LargestForest(%v): got (%v, %v), expected (%v, %v)", test.length, tag, size, test.tag, test.size)
+		}
+	}
+}
+
+func TestForestSizes(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+	var tag = func(word string) int {
+		var _, retval, _ = g.ForestTag(word)
+		return retval
+	}
+
+	var want = map[int]map[int]int{
+		1: {tag("a"): 2},
+		2: {tag("at"): 2},
+		3: {tag("cat"): 2, tag("dog"): 1},
+		4: {tag("cold"): 2, tag("fish"): 1},
+	}
+
+	for length, sizes := range want {
+		if got := g.Graphs[length].ForestSizes(); !reflect.DeepEqual(got, sizes) {
+			t.Errorf("ForestSizes() for length %v: got %v, expected %v", length, got, sizes)
+		}
+	}
+
+	if got := g.ForestSizesByLength(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ForestSizesByLength(): got %v, expected %v", got, want)
+	}
+
+	// Before exploring, everything counts under tag 0
+	var unexplored = newSubgraph(fixtureCatDog, false)
+	if got, want := unexplored.ForestSizes(), map[int]int{0: len(fixtureCatDog)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ForestSizes() unexplored: got %v, expected %v", got, want)
+	}
+}
+
+func TestForestDiameter(t *testing.T) {
+	// A path: cat - cot - cog - dog - dig, plus pin - pit on their own
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "dig", "pin", "pit"}).Graphs[3]
+
+	var tests = []struct {
+		word   string
+		s1, s2 string
+		steps  int
+	}{
+		{"cog", "cat", "dig", 4},
+		{"pin", "pin", "pit", 1},
+	}
+
+	for _, test := range tests {
+		var tag = g.node(test.word).ForestTag
+
+		if s1, s2, steps := g.ForestDiameter(tag); s1 != test.s1 || s2 != test.s2 || steps != test.steps {
+			t.Errorf("ForestDiameter of %v's forest: got (%v, %v, %v), expected (%v, %v, %v)", test.word, s1, s2, steps, test.s1, test.s2, test.steps)
+		}
+	}
+
+	if s1, s2, steps := g.ForestDiameter(999); s1 != "" || s2 != "" || steps != 0 {
+		t.Errorf("ForestDiameter of a missing forest: got (%v, %v, %v)", s1, s2, steps)
+	}
+
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, _, err := g.ForestDiameterContext(ctx, g.node("cat").ForestTag); err != context.Canceled {
+		t.Errorf("got %v after cancelling, expected context.Canceled", err)
+	}
+}
+
+func TestDegree(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+
+	var tests = []struct {
+		word   string
+		degree int
+		found  bool
+	}{
+		{"cot", 4, true},
+		{"cat", 3, true},
+		{"hot", 3, true},
+		{"bat", 2, true},
+		{"dog", 2, true},
+		{"cut", 0, false},
+	}
+
+	for _, test := range tests {
+		if degree, found := g.Degree(test.word); degree != test.degree || found != test.found {
+			t.Errorf("Degree(%v): got %v, %v, expected %v, %v", test.word, degree, found, test.degree, test.found)
+		}
+	}
+}
+
+func TestMostConnectedWords(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+
+	var tests = []struct {
+		n    int
+		want []string
+	}{
+		{1, []string{"cot"}},
+		{3, []string{"cot", "cat", "dot"}}, // cat, dot, hat and hot tie on 3
+		{0, []string{}},
+		{-1, []string{}},
+		{100, []string{"cot", "cat", "dot", "hat", "hot", "bat", "cog", "dog"}},
+	}
+
+	for _, test := range tests {
+		if got := g.Graphs[3].MostConnectedWords(test.n); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("MostConnectedWords(%v): got %v, expected %v", test.n, got, test.want)
+		}
+	}
+}
+
+func TestEndpointDegrees(t *testing.T) {
+	// cat-cot and dig-fig-fin are separate forests, and xyz has no neighbors at all
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "dig", "fig", "fin", "xyz"}).Graphs[3]
+
+	var tests = []struct {
+		s1, s2 string
+		d1, d2 int
+	}{
+		{"cat", "xyz", 1, 0}, // An isolated endpoint
+		{"xyz", "fig", 0, 2},
+		{"cat", "dig", 1, 1}, // Separate forests, neither a dead end
+		{"cot", "cot", 1, 1},
+		{"cat", "cut", 1, -1},
+		{"cut", "cog", -1, -1},
+	}
+
+	for _, test := range tests {
+		if test.d1 >= 0 && test.d2 >= 0 && test.s1 != test.s2 && g.ShortestPath(test.s1, test.s2) != nil {
+			t.Errorf("%v -> %v should have no path", test.s1, test.s2)
+		}
+
+		if d1, d2 := g.EndpointDegrees(test.s1, test.s2); d1 != test.d1 || d2 != test.d2 {
+			t.Errorf("EndpointDegrees(%v, %v): got %v, %v, expected %v, %v", test.s1, test.s2, d1, d2, test.d1, test.d2)
+		}
+	}
+}
+
+func TestIsolatedWords(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		length int
+		want   []string
+	}{
+		{1, []string{}},
+		{2, []string{}},
+		{3, []string{"dog"}},
+		{4, []string{"fish"}},
+	}
+
+	for _, test := range tests {
+		if got := g.Graphs[test.length].IsolatedWords(); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v letters: got %v, expected %v", test.length, got, test.want)
+		}
+	}
+
+	var want = map[int][]string{3: {"dog"}, 4: {"fish"}}
+	if got := g.IsolatedWords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+}
+
+func TestWordsWithinSteps(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var tests = []struct {
+		word string
+		n    int
+		want map[string]int
+	}{
+		{"cat", 0, map[string]int{"cat": 0}},
+		{"cat", 1, map[string]int{"cat": 0, "bat": 1, "cot": 1, "hat": 1}},
+		{"cat", 2, map[string]int{"cat": 0, "bat": 1, "cot": 1, "hat": 1, "cog": 2, "dot": 2, "hot": 2}},
+		{"cat", 10, map[string]int{"cat": 0, "bat": 1, "cot": 1, "hat": 1, "cog": 2, "dot": 2, "hot": 2, "dog": 3}},
+		{"dog", 1, map[string]int{"dog": 0, "cog": 1, "dot": 1}},
+		{"cat", -1, map[string]int{}},
+		{"cut", 2, map[string]int{}},
+	}
+
+	for _, test := range tests {
+		if got := g.WordsWithinSteps(test.word, test.n); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("WordsWithinSteps(%v, %v): got %v, expected %v", test.word, test.n, got, test.want)
+		}
+	}
+}
+
+func TestArticulationWords(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+		want  []string
+	}{
+		// A barbell: the loop cat-hat-hot-cot and the triangle cot-cog-con only meet at cot
+		{"barbell", []string{"cat", "hat", "hot", "cot", "cog", "con"}, []string{"cot"}},
+		{"chain", []string{"cat", "cot", "cog", "dog"}, []string{"cog", "cot"}},
+		{"loop", []string{"cat", "cot", "cog", "bog", "bot", "bat"}, []string{}},
+		{"pair", []string{"cat", "cot"}, []string{}},
+		{"one word", []string{"cat"}, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words).Graphs[3]
+
+			if got := g.ArticulationWords(g.WordGraph["cat"].ForestTag); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, expected %v", got, test.want)
+			}
+
+			// Taking any of them out really does split the forest
+			for _, word := range test.want {
+				var before = g.GetTotalForests()
+				var without = NewWordGraphFromWords(test.words)
+				without.RemoveWord(word)
+
+				if got := without.GetTotalForests(); got <= before {
+					t.Errorf("removing %v left %v forests, expected more than %v", word, got, before)
+				}
+			}
+		})
+	}
+
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+	if got := g.ArticulationWords(999); len(got) != 0 {
+		t.Errorf("got %v for a forest that doesn't exist", got)
+	}
+}
@@ -0,0 +1,630 @@
+// Package wordladder builds graphs of dictionary words connected by single-letter
+// changes and finds ladders between them.
+//
+// A graph isn't safe to change from one goroutine while another uses it.  Once it's built and
+// ExploreForests has run, call Freeze: after that nothing changes it, and it's safe to search
+// from as many goroutines as you like.
+package wordladder
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * Set of graphs of different length words.
+ */
+type WordGraph struct {
+	Graphs           map[int]*WordGraphOfSameLength          // Map of length to graph
+	Rules            EdgeRules                               // Passed along to each subgraph when exploring
+	Directed         bool                                    `json:"-"` // Passed along to each subgraph when exploring
+	NeighborFunc     func(a, b string) bool                  `json:"-"` // Passed along to each subgraph when exploring
+	UseWildcardIndex bool                                    `json:"-"` // Passed along to each subgraph when exploring
+	LazyNeighbors    bool                                    `json:"-"` // Passed along to each subgraph when exploring
+	ForestsOnly      bool                                    `json:"-"` // Keep only forest tags after exploring, not neighbor lists; see ExploreForests
+	Storage          StorageKind                             `json:"-"` // How subgraphs keep their words; passed along when they're created or explored
+	IgnoreCase       bool                                    `json:"-"` // Lowercase words when loading and looking them up
+	Log              io.Writer                               `json:"-"` // Where ExploreForests reports progress, nil for nowhere
+	WordFilter       func(string) bool                       `json:"-"` // Which words to load, nil for IsValidWord
+	Alphabet         []rune                                  `json:"-"` // Letters words may use, nil for any lowercase letter; passed along to each subgraph
+	MinLength        int                                     `json:"-"` // Shortest words to load, 0 for no limit
+	MaxLength        int                                     `json:"-"` // Longest words to load, 0 for no limit
+	frozen           bool                                    // See Freeze
+	dirty            bool                                    // See IsDirty
+	pathCache        *pathCache                              // See EnablePathCache, nil when off
+	progress         func(length, wordsDone, wordsTotal int) // See SetProgressFunc
+	progressLock     sync.Mutex                              // Subgraphs report from different goroutines
+	logLock          sync.Mutex                              // And log from them too, so Log doesn't have to be goroutine-safe
+}
+
+// Initialize
+func NewWordGraph() *WordGraph {
+	return &WordGraph{Graphs: make(map[int]*WordGraphOfSameLength)}
+}
+
+// Initialize with extra kinds of edges allowed
+func NewWordGraphWithRules(rules EdgeRules) *WordGraph {
+	var retval = NewWordGraph()
+	retval.Rules = rules
+	return retval
+}
+
+// Initialize with the words kept in the given kind of storage.  SortedStorage takes less memory a
+// word than the default MapStorage, and is a little slower to look words up in.
+func NewWordGraphWithStorage(kind StorageKind) *WordGraph {
+	var retval = NewWordGraph()
+	retval.Storage = kind
+	return retval
+}
+
+// Build a graph from the given words and explore its forests, ready to search.
+// Handy for tests and benchmarks that don't want to read a dictionary file.
+func NewWordGraphFromWords(words []string) *WordGraph {
+	var retval = NewWordGraph()
+
+	for _, word := range words {
+		retval.AddWord(word)
+	}
+
+	retval.ExploreForests()
+
+	return retval
+}
+
+// Lowercase a word if we're ignoring case, otherwise leave it alone
+func (g *WordGraph) fold(word string) string {
+	if g.IgnoreCase {
+		return strings.ToLower(word)
+	}
+
+	return word
+}
+
+// Whether words have been added since forests were last explored.  Their neighbors and forests aren't
+// known yet, so forest tags can't be trusted.  AddWordAndLink, RemoveWord and Link keep the forests
+// up to date, so they don't count.  Every query that reads neighbors or forest tags (ShortestPath,
+// Neighbors, ForestTag, Stats and so on) explores first if the graph is dirty, rather than give
+// wrong answers.  Validate doesn't, since unexplored words are one of the things it reports.
+func (g *WordGraph) IsDirty() bool {
+	return g.dirty
+}
+
+// Explore forests again if words have been added since last time
+func (g *WordGraph) exploreIfDirty() {
+	if g.dirty {
+		g.ExploreForests()
+	}
+}
+
+// Mark the graph read-only, so it can be searched from many goroutines at once.
+// Anything that would change it afterwards (adding, removing or loading words, or exploring) panics.
+// Freeze after ExploreForests; it also builds the lookup tables that searches would otherwise
+// build lazily, so reads never write.
+func (g *WordGraph) Freeze() {
+	g.exploreIfDirty()
+
+	for _, subgraph := range g.Graphs {
+		// So lookups never have to look through words waiting to be sorted
+		if subgraph.sorted != nil {
+			subgraph.sorted.compact()
+		}
+
+		if subgraph.deletionIndex == nil {
+			subgraph.buildDeletionIndex()
+		}
+
+		// Lazy neighbors are looked up in these
+		if subgraph.LazyNeighbors && subgraph.NeighborFunc == nil && subgraph.wildcardIndex == nil {
+			subgraph.buildWildcardIndex()
+		}
+
+		if subgraph.LazyNeighbors && subgraph.Rules.Anagrams && subgraph.anagramIndex == nil {
+			subgraph.buildAnagramIndex()
+		}
+	}
+
+	g.frozen = true
+}
+
+// Whether Freeze has been called
+func (g *WordGraph) IsFrozen() bool {
+	return g.frozen
+}
+
+// Panic if the graph has been frozen
+func (g *WordGraph) mustNotBeFrozen(op string) {
+	if g.frozen {
+		panic("wordladder: " + op + " called on a frozen graph")
+	}
+}
+
+// Add a word to the appropriate subgraph
+func (g *WordGraph) AddWord(word string) {
+	g.mustNotBeFrozen("AddWord")
+
+	word = g.fold(word)
+
+	var l = wordLength(word)
+
+	if g.Graphs == nil {
+		// A zero WordGraph rather than one from NewWordGraph
+		g.Graphs = make(map[int]*WordGraphOfSameLength)
+	}
+
+	_, present := g.Graphs[l]
+	if !present {
+		// Create new map of the right length
+		g.Graphs[l] = NewWordGraphOfSameLength(l)
+		g.Graphs[l].useStorage(g.Storage)
+	}
+
+	if g.Graphs[l].node(word) == nil {
+		// It has no neighbors or forest until the next ExploreForests
+		g.dirty = true
+	}
+
+	// Can't fail, we picked the subgraph by length
+	_ = g.Graphs[l].AddWord(word)
+	g.changed()
+}
+
+// Add a word to an already explored graph, linking it in without re-exploring.
+// See WordGraphOfSameLength.AddWordAndLink.
+func (g *WordGraph) AddWordAndLink(word string) {
+	g.mustNotBeFrozen("AddWordAndLink")
+
+	word = g.fold(word)
+
+	var l = wordLength(word)
+
+	if g.Graphs == nil {
+		g.Graphs = make(map[int]*WordGraphOfSameLength)
+	}
+
+	_, present := g.Graphs[l]
+	if !present {
+		g.Graphs[l] = NewWordGraphOfSameLength(l)
+		g.Graphs[l].Rules = g.Rules
+		g.Graphs[l].UseWildcardIndex = g.UseWildcardIndex
+		g.Graphs[l].LazyNeighbors = g.LazyNeighbors || g.ForestsOnly
+		g.Graphs[l].Directed = g.Directed
+		g.Graphs[l].NeighborFunc = g.NeighborFunc
+		g.Graphs[l].Alphabet = g.Alphabet
+		g.Graphs[l].useStorage(g.Storage)
+	}
+	g.Graphs[l].AddWordAndLink(word)
+	g.changed()
+}
+
+// Join two existing words of the same length with an edge.  See WordGraphOfSameLength.Link.
+func (g *WordGraph) Link(word1 string, word2 string) error {
+	g.mustNotBeFrozen("Link")
+
+	word1, word2 = g.fold(word1), g.fold(word2)
+
+	if wordLength(word1) != wordLength(word2) {
+		return ErrLengthMismatch
+	}
+
+	var subgraph = g.Graphs[wordLength(word1)]
+	if subgraph == nil {
+		return ErrWordNotFound{Word: word1}
+	}
+
+	g.changed()
+	return subgraph.Link(word1, word2)
+}
+
+// Swap one word for another of the same length.  See WordGraphOfSameLength.Replace.
+func (g *WordGraph) Replace(oldWord string, newWord string) error {
+	g.mustNotBeFrozen("Replace")
+
+	oldWord, newWord = g.fold(oldWord), g.fold(newWord)
+
+	if wordLength(oldWord) != wordLength(newWord) {
+		return ErrLengthMismatch
+	}
+
+	var subgraph = g.Graphs[wordLength(oldWord)]
+	if subgraph == nil {
+		return ErrWordNotFound{Word: oldWord}
+	}
+
+	g.changed()
+	return subgraph.Replace(oldWord, newWord)
+}
+
+// Should this word be loaded?  It can't be empty, has to be within MinLength and MaxLength and spelled
+// from the Alphabet (if there is one), and then it's up to the WordFilter if there is one.
+func (g *WordGraph) isValidWord(word string) bool {
+	var l = wordLength(word)
+	if l == 0 || (g.MinLength > 0 && l < g.MinLength) || (g.MaxLength > 0 && l > g.MaxLength) {
+		return false
+	}
+
+	if g.Alphabet != nil && !inAlphabet(word, g.Alphabet) {
+		return false
+	}
+
+	if g.WordFilter != nil {
+		return g.WordFilter(word)
+	}
+
+	return g.Alphabet != nil || IsValidWord(&word)
+}
+
+// Read words line by line from r, adding each valid one to the graph.
+// Loading is additive, so several word lists can be merged by loading each in turn (and then
+// running ExploreForests again).  Leading and trailing whitespace is trimmed from each line, so
+// Windows line endings and stray spaces don't lose words, and blank lines are skipped.
+// Returns the number of new words added and any error from reading.
+func (g *WordGraph) LoadFromReader(r io.Reader) (int, error) {
+	g.mustNotBeFrozen("LoadFromReader")
+
+	var retval = 0
+
+	var scanner = bufio.NewScanner(r)
+	for scanner.Scan() {
+		var word = g.fold(strings.TrimSpace(scanner.Text()))
+		if g.isValidWord(word) && g.node(word) == nil {
+			g.AddWord(word)
+			retval++
+		}
+	}
+
+	return retval, scanner.Err()
+}
+
+// Add a whole subgraph (say from LoadSubgraph), replacing any words of its length already in the graph
+func (g *WordGraph) AddSubgraph(subgraph *WordGraphOfSameLength) {
+	g.mustNotBeFrozen("AddSubgraph")
+
+	if g.Graphs == nil {
+		g.Graphs = make(map[int]*WordGraphOfSameLength)
+	}
+
+	g.Graphs[subgraph.WordLength] = subgraph
+	subgraph.useStorage(g.Storage)
+
+	// Like a loaded graph, take these from the subgraph (see reindex)
+	if subgraph.Directed {
+		g.Directed = true
+	}
+
+	if subgraph.LazyNeighbors {
+		g.LazyNeighbors = true
+	}
+
+	g.Rules = g.Rules.merge(subgraph.Rules)
+
+	g.changed()
+}
+
+// Remove a word from the appropriate subgraph.  False if the word wasn't there.
+func (g *WordGraph) RemoveWord(word string) bool {
+	g.mustNotBeFrozen("RemoveWord")
+
+	word = g.fold(word)
+
+	var subgraph = g.Graphs[wordLength(word)]
+	if subgraph == nil {
+		return false
+	}
+
+	g.changed()
+	return subgraph.RemoveWord(word)
+}
+
+// Report progress to the log, if there is one.  Exploring workers call this at the same time, so the
+// writes are serialized here.
+func (g *WordGraph) logf(format string, args ...interface{}) {
+	if g.Log == nil {
+		return
+	}
+
+	g.logLock.Lock()
+	defer g.logLock.Unlock()
+
+	fmt.Fprintf(g.Log, format, args...)
+}
+
+// Have ExploreForests call fn every so often with how far along each subgraph is.  Calls are
+// never concurrent, and each subgraph's last call has wordsDone == wordsTotal.  Nil turns it off.
+func (g *WordGraph) SetProgressFunc(fn func(length, wordsDone, wordsTotal int)) {
+	g.progress = fn
+}
+
+// Explore every subgraph, finding all forests and neighbors.  Subgraphs are independent, so they're
+// handed out to a pool of one worker per CPU.
+// With ForestsOnly only the forest tags are kept, which is all AreTwoWordsConnected needs.
+// Searches still work, finding neighbors as they go like LazyNeighbors does.
+// Changing Rules, Directed or NeighborFunc and exploring again starts those words over (see
+// WordGraphOfSameLength.ExploreAllForests).
+func (g *WordGraph) ExploreForests() {
+	g.ExploreForestsTimed()
+}
+
+// Like ExploreForests, but also reports how long each word length took, to see which dominates.
+func (g *WordGraph) ExploreForestsTimed() ExploreReport {
+	g.mustNotBeFrozen("ExploreForests")
+	g.changed()
+	g.dirty = false
+
+	var start = time.Now()
+	var retval = ExploreReport{Lengths: make(map[int]LengthReport)}
+	var reportLock sync.Mutex
+
+	var work = make(chan *WordGraphOfSameLength)
+	var wg sync.WaitGroup
+
+	for i := 0; i < runtime.NumCPU(); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for sg := range work {
+				g.logf("[%v] Working on subgraph for %v-length words.\n", sg.WordLength, sg.WordLength)
+				var started = time.Now()
+				sg.ExploreAllForests()
+				var report = LengthReport{Duration: time.Since(started), Words: sg.GetTotalWords(), Forests: sg.GetTotalForests()}
+				g.logf("--> [%v] Processed %v words into %v forests.\n", sg.WordLength, report.Words, report.Forests)
+
+				reportLock.Lock()
+				retval.Lengths[sg.WordLength] = report
+				reportLock.Unlock()
+			}
+		}()
+	}
+
+	for _, subgraph := range g.Graphs {
+		subgraph.Rules = g.Rules
+		subgraph.UseWildcardIndex = g.UseWildcardIndex
+		var lazy = g.LazyNeighbors || g.ForestsOnly
+		if subgraph.LazyNeighbors && !lazy {
+			// Nothing was stored while it was lazy, so every word's neighbors need working out again
+			subgraph.eachNode(func(v *WordNode) {
+				v.ForestTag = 0
+			})
+		}
+
+		subgraph.LazyNeighbors = lazy
+		subgraph.forestsOnly = g.ForestsOnly
+		subgraph.Directed = g.Directed
+		subgraph.NeighborFunc = g.NeighborFunc
+		subgraph.Alphabet = g.Alphabet
+		subgraph.useStorage(g.Storage)
+		subgraph.progress = nil
+
+		if g.progress != nil {
+			var length = subgraph.WordLength
+
+			subgraph.progress = func(done, total int) {
+				g.progressLock.Lock()
+				defer g.progressLock.Unlock()
+
+				g.progress(length, done, total)
+			}
+		}
+
+		work <- subgraph
+	}
+
+	// Wait for all to finish
+	close(work)
+	wg.Wait()
+
+	retval.Duration = time.Since(start)
+
+	return retval
+}
+
+// Does a path exist between two strings?  Figure out what length we're looking at and pass it along
+func (g *WordGraph) AreTwoWordsConnected(s1 string, s2 string) bool {
+	g.exploreIfDirty()
+
+	s1, s2 = g.fold(s1), g.fold(s2)
+
+	if wordLength(s1) != wordLength(s2) || g.Graphs[wordLength(s1)] == nil {
+		return false
+	}
+
+	return g.Graphs[wordLength(s1)].AreTwoWordsConnected(s1, s2)
+}
+
+// Return a shortest path from s1 to s2, starting with s1 and ending with s2.  Nil if no path exists.
+// Answers come from the path cache if it's enabled (see EnablePathCache).
+func (g *WordGraph) ShortestPath(s1 string, s2 string) []string {
+	g.exploreIfDirty()
+
+	s1, s2 = g.fold(s1), g.fold(s2)
+
+	if wordLength(s1) != wordLength(s2) || g.Graphs[wordLength(s1)] == nil {
+		return nil
+	}
+
+	if g.pathCache == nil {
+		return g.Graphs[wordLength(s1)].ShortestPath(s1, s2)
+	}
+
+	if retval, cached := g.pathCache.get(s1, s2); cached {
+		return retval
+	}
+
+	var retval = g.Graphs[wordLength(s1)].ShortestPath(s1, s2)
+	g.pathCache.put(s1, s2, retval)
+
+	return retval
+}
+
+// Like ShortestPath, but says why there's no path: ErrLengthMismatch, ErrWordNotFound, or ErrNoPath
+func (g *WordGraph) ShortestPathE(s1 string, s2 string) ([]string, error) {
+	g.exploreIfDirty()
+
+	s1, s2 = g.fold(s1), g.fold(s2)
+
+	if wordLength(s1) != wordLength(s2) {
+		return nil, ErrLengthMismatch
+	}
+
+	for _, word := range []string{s1, s2} {
+		if g.node(word) == nil {
+			return nil, ErrWordNotFound{Word: word}
+		}
+	}
+
+	var retval = g.Graphs[wordLength(s1)].ShortestPath(s1, s2)
+	if retval == nil {
+		return nil, ErrNoPath
+	}
+
+	return retval, nil
+}
+
+// Find the node for a word in the appropriate subgraph.  Nil if it isn't there.
+func (g *WordGraph) node(word string) *WordNode {
+	var subgraph = g.Graphs[wordLength(word)]
+	if subgraph == nil {
+		return nil
+	}
+
+	return subgraph.node(word)
+}
+
+// Is the word in the graph?
+func (g *WordGraph) Contains(word string) bool {
+	return g.node(g.fold(word)) != nil
+}
+
+// List the words one change away from word.  False if the word isn't in the graph.
+func (g *WordGraph) Neighbors(word string) ([]string, bool) {
+	g.exploreIfDirty()
+
+	word = g.fold(word)
+
+	var node = g.node(word)
+	if node == nil {
+		return nil, false
+	}
+
+	var neighbors = g.Graphs[wordLength(word)].neighborsOf(node)
+
+	var retval = make([]string, len(neighbors))
+	for i, neighborWord := range neighbors {
+		retval[i] = *neighborWord
+	}
+
+	return retval, true
+}
+
+// Which forest a word lives in.  Forest tags are only unique within a word length, so the
+// length is returned too; together they identify the forest across the whole graph.
+func (g *WordGraph) ForestTag(word string) (length int, tag int, ok bool) {
+	g.exploreIfDirty()
+
+	word = g.fold(word)
+
+	var node = g.node(word)
+	if node == nil {
+		return 0, 0, false
+	}
+
+	return wordLength(word), node.ForestTag, true
+}
+
+// Number of steps in a shortest path from s1 to s2, and whether a path exists at all
+func (g *WordGraph) LadderLength(s1 string, s2 string) (int, bool) {
+	g.exploreIfDirty()
+
+	s1, s2 = g.fold(s1), g.fold(s2)
+
+	if wordLength(s1) != wordLength(s2) || g.Graphs[wordLength(s1)] == nil {
+		return 0, false
+	}
+
+	return g.Graphs[wordLength(s1)].LadderLength(s1, s2)
+}
+
+// Can a ladder go straight from s1 to s2?  That's whatever exploring made an edge: the NeighborFunc
+// or one change apart, the rules, and links.  In a Directed graph only the forward step counts.
+func (g *WordGraph) isStep(s1 string, s2 string) bool {
+	var subgraph = g.Graphs[wordLength(s1)]
+	if subgraph == nil || subgraph.node(s1) == nil {
+		return false
+	}
+
+	return hasNeighbor(subgraph.neighborsOf(subgraph.node(s1)), s2)
+}
+
+// Check a proposed ladder: every word has to be in the graph, and each one a single step (an edge in
+// the graph, see isStep) from the one before.  Returns whether it's valid, and if not, the index of
+// the first word that's unknown or can't be reached from the previous one (-1 if valid).
+// An empty ladder isn't valid, and fails at index 0.
+func (g *WordGraph) IsValidLadder(path []string) (bool, int) {
+	if len(path) == 0 {
+		return false, 0
+	}
+
+	g.exploreIfDirty()
+
+	for i := range path {
+		var word = g.fold(path[i])
+
+		if g.node(word) == nil {
+			return false, i
+		}
+
+		if i > 0 && !g.isStep(g.fold(path[i-1]), word) {
+			return false, i
+		}
+	}
+
+	return true, -1
+}
+
+func (g *WordGraph) GetTotalWords() int {
+	var retval = 0
+
+	for _, subgraph := range g.Graphs {
+		retval += subgraph.GetTotalWords()
+	}
+
+	return retval
+}
+
+// Call fn with every word in the graph, shortest words first and alphabetically within a length.
+func (g *WordGraph) EachWord(fn func(word string)) {
+	var lengths = make([]int, 0, len(g.Graphs))
+	for length := range g.Graphs {
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+
+	for _, length := range lengths {
+		for _, word := range g.Graphs[length].Words() {
+			fn(word)
+		}
+	}
+}
+
+func (g *WordGraph) GetTotalDistinctWordLengths() int {
+	return len(g.Graphs)
+}
+
+func (g *WordGraph) GetTotalForests() int {
+	g.exploreIfDirty()
+
+	var retval = 0
+
+	for _, subgraph := range g.Graphs {
+		retval += subgraph.GetTotalForests()
+	}
+
+	return retval
+}
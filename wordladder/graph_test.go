@@ -0,0 +1,1299 @@
+package wordladder
+
+import (
+	"bytes"
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestExploreForestsLogsEveryLength(t *testing.T) {
+	var g = NewWordGraph()
+	var log bytes.Buffer
+	g.Log = &log
+
+	// Several lengths, so several workers log at once (run with -race)
+	for _, word := range []string{"a", "i", "at", "it", "cat", "cot", "cold", "cord", "plant", "plans", "planet"} {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	for _, length := range []string{"[1]", "[2]", "[3]", "[4]", "[5]", "[6]"} {
+		if !strings.Contains(log.String(), "--> "+length+" Processed") {
+			t.Errorf("log has no summary for %v:\n%v", length, log.String())
+		}
+	}
+}
+
+func TestIsValidLadder(t *testing.T) {
+	var tests = []struct {
+		name     string
+		directed bool
+		link     []string
+		path     []string
+		valid    bool
+		at       int
+	}{
+		{"ladder", false, nil, []string{"cat", "cot", "cog", "dog"}, true, -1},
+		{"empty", false, nil, []string{}, false, 0},
+		{"unknown word", false, nil, []string{"cat", "cut"}, false, 1},
+		{"too far", false, nil, []string{"cat", "cog"}, false, 1},
+		{"one word", false, nil, []string{"cat"}, true, -1},
+		{"unknown first word", false, nil, []string{"cut", "cat"}, false, 0},
+		{"unknown word in the middle", false, nil, []string{"cat", "cot", "cut", "dog"}, false, 2},
+		{"non-neighbor step later on", false, nil, []string{"cat", "cot", "dot", "hat"}, false, 3},
+		{"standing still", false, nil, []string{"cat", "cat"}, false, 1},
+		{"link", false, []string{"cat", "dog"}, []string{"cat", "dog"}, true, -1},
+		{"link backwards", false, []string{"cat", "dog"}, []string{"dog", "cat"}, true, -1},
+		{"directed link", true, []string{"cat", "dog"}, []string{"cat", "dog"}, true, -1},
+		{"directed link backwards", true, []string{"cat", "dog"}, []string{"dog", "cat"}, false, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.Directed = test.directed
+			for _, word := range fixtureCatDog {
+				g.AddWord(word)
+			}
+			g.ExploreForests()
+
+			if test.link != nil {
+				if err := g.Link(test.link[0], test.link[1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if valid, at := g.IsValidLadder(test.path); valid != test.valid || at != test.at {
+				t.Errorf("got (%v, %v), expected (%v, %v)", valid, at, test.valid, test.at)
+			}
+		})
+	}
+}
+
+func TestIsValidLadderAcceptsShortestPath(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+	if err := g.Link("cat", "dog"); err != nil {
+		t.Fatal(err)
+	}
+
+	var path = g.ShortestPath("cat", "dog")
+	if valid, at := g.IsValidLadder(path); !valid {
+		t.Errorf("%v should be valid, failed at %v", path, at)
+	}
+}
+
+func TestDirectedPaths(t *testing.T) {
+	var g = NewWordGraph()
+	g.Directed = true
+	for _, word := range []string{"cat", "cot", "zzz"} {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	if err := g.Link("zzz", "cat"); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		s1, s2    string
+		connected bool
+		path      []string
+	}{
+		{"zzz", "cot", true, []string{"zzz", "cat", "cot"}},
+		{"cot", "zzz", false, nil},
+		{"cat", "cot", true, []string{"cat", "cot"}},
+		{"cot", "cat", true, []string{"cot", "cat"}},
+	}
+
+	for _, test := range tests {
+		if got := g.AreTwoWordsConnected(test.s1, test.s2); got != test.connected {
+			t.Errorf("AreTwoWordsConnected(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.connected)
+		}
+
+		if got := g.ShortestPath(test.s1, test.s2); !samePath(got, test.path) {
+			t.Errorf("ShortestPath(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.path)
+		}
+	}
+}
+
+func TestDirtyGraphExploresBeforeQueries(t *testing.T) {
+	var tests = []struct {
+		name  string
+		query func(g *WordGraph) bool
+	}{
+		{"AreTwoWordsConnected", func(g *WordGraph) bool { return g.AreTwoWordsConnected("cart", "card") }},
+		{"ShortestPath", func(g *WordGraph) bool { return samePath(g.ShortestPath("cart", "card"), []string{"cart", "card"}) }},
+		{"ShortestPathEdit", func(g *WordGraph) bool { return len(g.ShortestPathEdit("cot", "card")) == 4 }},
+		{"LadderLength", func(g *WordGraph) bool { n, ok := g.LadderLength("cart", "card"); return ok && n == 1 }},
+		{"Neighbors", func(g *WordGraph) bool { n, _ := g.Neighbors("cart"); return samePath(n, []string{"card"}) }},
+		{"Degree", func(g *WordGraph) bool { n, _ := g.Degree("cart"); return n == 1 }},
+		{"ForestTag", func(g *WordGraph) bool { _, tag, _ := g.ForestTag("cart"); return tag > 0 }},
+		{"WordsInForestOf", func(g *WordGraph) bool { return len(g.WordsInForestOf("cart")) == 2 }},
+		{"ForestSizeHistogram", func(g *WordGraph) bool { return g.ForestSizeHistogram(4)[0] == 0 }},
+		{"IsolatedWords", func(g *WordGraph) bool { return len(g.IsolatedWords()[4]) == 0 }},
+		{"GetTotalForests", func(g *WordGraph) bool { return g.GetTotalForests() == 2 }},
+		{"Stats", func(g *WordGraph) bool { return g.Stats().TotalEdges == 3 }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords([]string{"cat", "cot", "car", "card"})
+			g.AddWord("cart")
+
+			if !g.IsDirty() {
+				t.Fatalf("adding a word should make the graph dirty")
+			}
+
+			if !test.query(g) {
+				t.Errorf("gave a stale answer")
+			}
+
+			if g.IsDirty() {
+				t.Errorf("querying should have explored the graph")
+			}
+		})
+	}
+}
+
+// Random words from a small alphabet, so there are plenty of neighbors
+func randomWords(n int, length int, seed int64) []string {
+	var r = rand.New(rand.NewSource(seed))
+	var retval = make([]string, n)
+
+	for i := range retval {
+		var word = make([]byte, length)
+		for j := range word {
+			word[j] = byte('a' + r.Intn(12))
+		}
+		retval[i] = string(word)
+	}
+
+	return retval
+}
+
+// Heap in use once the garbage is collected
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+func TestForestsOnly(t *testing.T) {
+	var words = randomWords(8000, 4, 1)
+
+	var build = func(store bool) (*WordGraph, uint64) {
+		var before = heapInUse()
+
+		var g = NewWordGraph()
+		g.UseWildcardIndex = true
+		g.ForestsOnly = !store
+		for _, word := range words {
+			g.AddWord(word)
+		}
+		g.ExploreForests()
+
+		var after = heapInUse()
+		if after < before {
+			return g, 0
+		}
+		return g, after - before
+	}
+
+	var stored, storedBytes = build(true)
+	var forests, forestsBytes = build(false)
+
+	if !reflect.DeepEqual(forests.ForestSizeHistogram(4), stored.ForestSizeHistogram(4)) {
+		t.Errorf("forests differ without neighbors stored")
+	}
+
+	for _, word := range words[:200] {
+		var _, want, _ = stored.ForestTag(word)
+		var _, got, _ = forests.ForestTag(word)
+		if got != want {
+			t.Errorf("%v: got forest %v, expected %v", word, got, want)
+		}
+	}
+
+	// Searches work neighbors out as they go
+	for i := 0; i+1 < 100; i += 2 {
+		if got, want := forests.ShortestPath(words[i], words[i+1]), stored.ShortestPath(words[i], words[i+1]); !samePath(got, want) {
+			t.Errorf("ShortestPath(%v, %v): got %v, expected %v", words[i], words[i+1], got, want)
+		}
+	}
+
+	t.Logf("%v bytes with neighbors stored, %v without", storedBytes, forestsBytes)
+	if forestsBytes >= storedBytes/2 {
+		t.Errorf("forests only took %v bytes, expected well under the %v with neighbors stored", forestsBytes, storedBytes)
+	}
+
+	runtime.KeepAlive(stored)
+	runtime.KeepAlive(forests)
+}
+
+func TestForestsOnlyTurnedOff(t *testing.T) {
+	var g = NewWordGraph()
+	g.ForestsOnly = true
+	for _, word := range fixtureCatDog {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	g.ForestsOnly = false
+	g.ExploreForests()
+
+	if neighbors, _ := g.Neighbors("cat"); !samePath(neighbors, []string{"bat", "cot", "hat"}) {
+		t.Errorf("got neighbors %v after storing them again", neighbors)
+	}
+
+	if got := g.Graphs[3].WordGraph["cat"].Neighbors; len(got) != 3 {
+		t.Errorf("got %v stored neighbors, expected 3", len(got))
+	}
+}
+
+func TestLazyNeighbors(t *testing.T) {
+	var random = randomWords(2000, 4, 93)
+
+	var tests = []struct {
+		name     string
+		words    []string
+		useIndex bool
+		pairs    [][2]string
+	}{
+		{"cat-dog", fixtureCatDog, true, [][2]string{{"cat", "dog"}, {"bat", "cog"}, {"hat", "hat"}, {"cat", "cut"}}},
+		{"cold-warm", fixtureColdWarm, true, [][2]string{{"cold", "warm"}, {"cold", "fish"}, {"dish", "fish"}}},
+		{"random", random, true, [][2]string{{random[0], random[1]}, {random[2], random[3]}, {random[4], random[5]}}},
+		{"random by scan", random, false, [][2]string{{random[0], random[1]}, {random[6], random[7]}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var build = func(lazy bool) *WordGraph {
+				var g = NewWordGraph()
+				g.UseWildcardIndex = test.useIndex
+				g.LazyNeighbors = lazy
+				for _, word := range test.words {
+					g.AddWord(word)
+				}
+				g.ExploreForests()
+				return g
+			}
+
+			var stored, lazy = build(false), build(true)
+
+			for _, pair := range test.pairs {
+				if got, want := lazy.ShortestPath(pair[0], pair[1]), stored.ShortestPath(pair[0], pair[1]); !samePath(got, want) {
+					t.Errorf("ShortestPath(%v, %v): got %v lazily, expected %v", pair[0], pair[1], got, want)
+				}
+			}
+
+			if got, want := lazy.GetTotalForests(), stored.GetTotalForests(); got != want {
+				t.Errorf("got %v forests lazily, expected %v", got, want)
+			}
+
+			// Nothing stored, but the neighbors still come out the same
+			for _, word := range test.words[:min(len(test.words), 50)] {
+				var subgraph = lazy.Graphs[wordLength(word)]
+				if node := subgraph.node(word); len(node.Neighbors) != 0 {
+					t.Errorf("%v has %v stored neighbors", word, len(node.Neighbors))
+				}
+
+				got, _ := lazy.Neighbors(word)
+				want, _ := stored.Neighbors(word)
+				if !samePath(got, want) {
+					t.Errorf("%v: got neighbors %v lazily, expected %v", word, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRemoveWord(t *testing.T) {
+	var tests = []struct {
+		name      string
+		remove    string
+		removed   bool
+		s1, s2    string
+		connected bool
+		forests   int
+	}{
+		// cog is the only way between the cat and dog ends
+		{"bridge", "cog", true, "cat", "dog", false, 2},
+		{"leaf", "bat", true, "cat", "dog", true, 1},
+		{"endpoint", "dog", true, "cat", "cog", true, 1},
+		{"missing", "cow", false, "cat", "dog", true, 1},
+		{"wrong length", "cold", false, "cat", "dog", true, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords([]string{"bat", "cat", "cot", "cog", "dog"})
+
+			if got := g.RemoveWord(test.remove); got != test.removed {
+				t.Errorf("RemoveWord: got %v, expected %v", got, test.removed)
+			}
+
+			if test.removed && g.Contains(test.remove) {
+				t.Errorf("%v is still in the graph", test.remove)
+			}
+
+			if got := g.AreTwoWordsConnected(test.s1, test.s2); got != test.connected {
+				t.Errorf("AreTwoWordsConnected(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.connected)
+			}
+
+			if got := g.GetTotalForests(); got != test.forests {
+				t.Errorf("got %v forests, expected %v", got, test.forests)
+			}
+
+			if err := g.Validate(); err != nil {
+				t.Errorf("graph is inconsistent: %v", err)
+			}
+		})
+	}
+}
+
+// Random words of every length from 3 to 12, for exploring many subgraphs at once
+func manyLengths() []string {
+	var retval = []string{}
+
+	for length := 3; length <= 12; length++ {
+		retval = append(retval, randomWords(1500, length, int64(length))...)
+	}
+
+	return retval
+}
+
+func TestExploreForestsMatchesSerial(t *testing.T) {
+	var words = manyLengths()
+
+	var parallel = NewWordGraph()
+	var serial = NewWordGraph()
+	parallel.UseWildcardIndex = true
+	serial.UseWildcardIndex = true
+	for _, word := range words {
+		parallel.AddWord(word)
+		serial.AddWord(word)
+	}
+
+	parallel.ExploreForests()
+
+	for _, subgraph := range serial.Graphs {
+		subgraph.UseWildcardIndex = true
+		subgraph.ExploreAllForests()
+	}
+	serial.dirty = false
+
+	if got, want := forestTags(parallel), forestTags(serial); !reflect.DeepEqual(got, want) {
+		t.Errorf("parallel exploring gave different forests")
+	}
+
+	if got, want := parallel.Stats(), serial.Stats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, expected %+v", got, want)
+	}
+}
+
+// Exploring subgraphs of ten lengths one after another, and with ExploreForests' worker pool
+// (run with -bench ExploreForests)
+func BenchmarkExploreForests(b *testing.B) {
+	var words = manyLengths()
+
+	var build = func() *WordGraph {
+		var g = NewWordGraph()
+		g.UseWildcardIndex = true
+		for _, word := range words {
+			g.AddWord(word)
+		}
+		return g
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			var g = build()
+			b.StartTimer()
+
+			for _, subgraph := range g.Graphs {
+				subgraph.UseWildcardIndex = true
+				subgraph.ExploreAllForests()
+			}
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			b.StopTimer()
+			var g = build()
+			b.StartTimer()
+
+			g.ExploreForests()
+		}
+	})
+}
+
+func TestLadderLength(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		s1, s2 string
+		steps  int
+		ok     bool
+	}{
+		{"cat", "cot", 1, true},
+		{"cat", "cat", 0, true},
+		{"a", "i", 1, true},
+		{"cat", "dog", 0, false},
+		{"cat", "cold", 0, false},
+		{"cat", "cut", 0, false},
+	}
+
+	for _, test := range tests {
+		if steps, ok := g.LadderLength(test.s1, test.s2); steps != test.steps || ok != test.ok {
+			t.Errorf("LadderLength(%v, %v): got (%v, %v), expected (%v, %v)", test.s1, test.s2, steps, ok, test.steps, test.ok)
+		}
+	}
+
+	// Agrees with the length of the path on a longer ladder
+	var cold = NewWordGraphFromWords(fixtureColdWarm)
+	if steps, ok := cold.LadderLength("cold", "warm"); !ok || steps != len(cold.ShortestPath("cold", "warm"))-1 {
+		t.Errorf("cold -> warm: got (%v, %v), expected (4, true)", steps, ok)
+	}
+}
+
+func TestIgnoreCase(t *testing.T) {
+	var tests = []struct {
+		name       string
+		ignoreCase bool
+		dictionary string
+		path       []string
+	}{
+		{"lowercase dictionary", true, "cat\ncot\ncog\ndog\n", []string{"cat", "cot", "cog", "dog"}},
+		{"mixed case dictionary", true, "Cat\nCOT\ncog\nDog\n", []string{"cat", "cot", "cog", "dog"}},
+		{"case matters", false, "cat\ncot\ncog\ndog\n", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.IgnoreCase = test.ignoreCase
+
+			if _, err := g.LoadFromReader(strings.NewReader(test.dictionary)); err != nil {
+				t.Fatal(err)
+			}
+			g.ExploreForests()
+
+			if got := g.ShortestPath("CAT", "DOG"); !samePath(got, test.path) {
+				t.Errorf("ShortestPath(CAT, DOG): got %v, expected %v", got, test.path)
+			}
+
+			if got := g.AreTwoWordsConnected("Cat", "dOg"); got != (test.path != nil) {
+				t.Errorf("AreTwoWordsConnected(Cat, dOg): got %v", got)
+			}
+		})
+	}
+}
+
+func TestForestTag(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		s1, s2 string
+		same   bool
+	}{
+		{"cat", "cot", true},
+		{"cold", "cord", true},
+		{"cat", "dog", false},
+		{"a", "at", false},
+		{"cold", "fish", false},
+	}
+
+	for _, test := range tests {
+		var l1, tag1, ok1 = g.ForestTag(test.s1)
+		var l2, tag2, ok2 = g.ForestTag(test.s2)
+
+		if !ok1 || !ok2 || l1 != wordLength(test.s1) || l2 != wordLength(test.s2) || tag1 <= 0 || tag2 <= 0 {
+			t.Errorf("ForestTag(%v), ForestTag(%v): got (%v, %v, %v), (%v, %v, %v)", test.s1, test.s2, l1, tag1, ok1, l2, tag2, ok2)
+		}
+
+		if got := l1 == l2 && tag1 == tag2; got != test.same {
+			t.Errorf("%v and %v: same forest is %v, expected %v", test.s1, test.s2, got, test.same)
+		}
+	}
+
+	if length, tag, ok := g.ForestTag("cut"); ok || length != 0 || tag != 0 {
+		t.Errorf("ForestTag(cut): got (%v, %v, %v), expected (0, 0, false)", length, tag, ok)
+	}
+}
+
+func TestShortestPathE(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		s1, s2 string
+		path   []string
+		err    error
+	}{
+		{"cat", "cot", []string{"cat", "cot"}, nil},
+		{"cat", "cold", nil, ErrLengthMismatch},
+		{"cut", "cat", nil, ErrWordNotFound{Word: "cut"}},
+		{"cat", "cut", nil, ErrWordNotFound{Word: "cut"}},
+		{"cat", "dog", nil, ErrNoPath},
+	}
+
+	for _, test := range tests {
+		var path, err = g.ShortestPathE(test.s1, test.s2)
+
+		if !errors.Is(err, test.err) {
+			t.Errorf("ShortestPathE(%v, %v): got error %v, expected %v", test.s1, test.s2, err, test.err)
+		}
+
+		if !samePath(path, test.path) {
+			t.Errorf("ShortestPathE(%v, %v): got %v, expected %v", test.s1, test.s2, path, test.path)
+		}
+	}
+}
+
+func TestWordFilter(t *testing.T) {
+	var apostrophes = func(word string) bool {
+		for _, c := range word {
+			if (c < 'a' || c > 'z') && c != '\'' {
+				return false
+			}
+		}
+		return true
+	}
+
+	var tests = []struct {
+		name   string
+		filter func(string) bool
+		want   []string
+	}{
+		{"default", nil, []string{"dont", "wont"}},
+		{"apostrophes", apostrophes, []string{"don't", "dont", "won't", "wont"}},
+		{"nothing", func(string) bool { return false }, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.WordFilter = test.filter
+
+			if _, err := g.LoadFromReader(strings.NewReader("don't\ndont\nwon't\nwont\nDon\nx-ray\n")); err != nil {
+				t.Fatal(err)
+			}
+
+			var got = []string{}
+			for _, subgraph := range g.Graphs {
+				got = append(got, subgraph.Words()...)
+			}
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("loaded %v, expected %v", got, test.want)
+			}
+		})
+	}
+
+	// The apostrophe is just another letter, so don't and won't are neighbors
+	var g = NewWordGraph()
+	g.WordFilter = apostrophes
+	g.LoadFromReader(strings.NewReader("don't\nwon't\n"))
+	g.ExploreForests()
+
+	if got := g.ShortestPath("don't", "won't"); !samePath(got, []string{"don't", "won't"}) {
+		t.Errorf("got %v, expected [don't won't]", got)
+	}
+}
+
+func TestLoadFromReaderMerges(t *testing.T) {
+	var g = NewWordGraph()
+
+	var loads = []struct {
+		list  string
+		added int
+		words int
+		path  []string // cat to dog, after this load
+	}{
+		{"cat\ncot\n", 2, 2, nil},
+		{"cog\ndog\ncat\n", 2, 4, []string{"cat", "cot", "cog", "dog"}}, // cat is already there
+		{"cat\ncot\ncog\ndog\n", 0, 4, []string{"cat", "cot", "cog", "dog"}},
+	}
+
+	for i, load := range loads {
+		added, err := g.LoadFromReader(strings.NewReader(load.list))
+		if err != nil {
+			t.Fatal(err)
+		}
+		g.ExploreForests()
+
+		if added != load.added || g.GetTotalWords() != load.words {
+			t.Errorf("load %v: added %v for %v words, expected %v for %v", i, added, g.GetTotalWords(), load.added, load.words)
+		}
+
+		if got := g.ShortestPath("cat", "dog"); !samePath(got, load.path) {
+			t.Errorf("load %v: got %v, expected %v", i, got, load.path)
+		}
+	}
+
+	if got := g.GetTotalForests(); got != 1 {
+		t.Errorf("got %v forests, expected 1", got)
+	}
+}
+
+func TestLoadFromReaderTrimsLines(t *testing.T) {
+	var tests = []struct {
+		name string
+		list string
+		want []string
+	}{
+		{"crlf and spaces", "cat\r\n bat \ndog\n", []string{"bat", "cat", "dog"}},
+		{"tabs", "\tcat\t\ncot\n", []string{"cat", "cot"}},
+		{"blank lines", "cat\r\n\r\n   \ndog", []string{"cat", "dog"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+
+			added, err := g.LoadFromReader(strings.NewReader(test.list))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if added != len(test.want) {
+				t.Errorf("added %v words, expected %v", added, len(test.want))
+			}
+
+			for _, word := range test.want {
+				if !g.Contains(word) {
+					t.Errorf("%q wasn't loaded", word)
+				}
+			}
+		})
+	}
+}
+
+func TestEachWord(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+		want  []string // Shortest first, then alphabetical
+	}{
+		{"mixed", fixtureMixed, []string{"a", "i", "at", "it", "cat", "cot", "dog", "cold", "cord", "fish"}},
+		{"duplicates", []string{"dog", "cat", "dog"}, []string{"cat", "dog"}},
+		{"empty", []string{}, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words)
+
+			var got = []string{}
+			g.EachWord(func(word string) {
+				got = append(got, word)
+			})
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("EachWord: got %v, expected %v", got, test.want)
+			}
+
+			for l, subgraph := range g.Graphs {
+				var words = subgraph.Words()
+				if !sort.StringsAreSorted(words) || len(words) != subgraph.GetTotalWords() {
+					t.Errorf("%v letters: Words gave %v", l, words)
+				}
+			}
+		})
+	}
+}
+
+// Run with -race
+func TestFrozenConcurrentShortestPath(t *testing.T) {
+	var words = randomWords(800, 4, 5)
+
+	var tests = []struct {
+		name  string
+		setup func(g *WordGraph)
+	}{
+		{"map", func(g *WordGraph) {}},
+		{"sorted", func(g *WordGraph) { g.Storage = SortedStorage }},
+		{"forests only", func(g *WordGraph) { g.ForestsOnly = true }},
+		{"path cache", func(g *WordGraph) { g.EnablePathCache(50) }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.UseWildcardIndex = true
+			test.setup(g)
+			for _, word := range words {
+				g.AddWord(word)
+			}
+			g.Freeze()
+
+			// Answers worked out one at a time, to check the concurrent ones against
+			var pairs = [][2]string{}
+			var want = [][]string{}
+			for i := 0; i+1 < 80; i += 2 {
+				pairs = append(pairs, [2]string{words[i], words[i+1]})
+				want = append(want, g.ShortestPath(words[i], words[i+1]))
+			}
+
+			var wg sync.WaitGroup
+			for worker := 0; worker < 8; worker++ {
+				wg.Add(1)
+				go func(worker int) {
+					defer wg.Done()
+
+					for i := range pairs {
+						// Each worker starts somewhere different
+						var j = (i + worker*7) % len(pairs)
+
+						// Ties can come out either way, but not the length
+						if got := g.ShortestPath(pairs[j][0], pairs[j][1]); len(got) != len(want[j]) {
+							t.Errorf("%v -> %v: got %v, expected %v", pairs[j][0], pairs[j][1], got, want[j])
+						}
+					}
+				}(worker)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+func TestFreezeStopsChanges(t *testing.T) {
+	var changes = []struct {
+		name   string
+		change func(g *WordGraph)
+	}{
+		{"AddWord", func(g *WordGraph) { g.AddWord("cut") }},
+		{"AddWordAndLink", func(g *WordGraph) { g.AddWordAndLink("cut") }},
+		{"LoadFromReader", func(g *WordGraph) { g.LoadFromReader(strings.NewReader("cut\n")) }},
+	}
+
+	for _, test := range changes {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(fixtureCatDog)
+			g.Freeze()
+
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%v on a frozen graph should panic", test.name)
+				}
+			}()
+
+			test.change(g)
+		})
+	}
+}
+
+func TestForestTagsDeterministic(t *testing.T) {
+	var reversed = func(words []string) []string {
+		var retval = make([]string, len(words))
+		for i, word := range words {
+			retval[len(words)-1-i] = word
+		}
+		return retval
+	}
+
+	var tests = []struct {
+		name  string
+		words []string
+	}{
+		{"cold warm", fixtureColdWarm},
+		{"mixed", fixtureMixed},
+		{"random", randomWords(800, 4, 9)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var first = forestTags(NewWordGraphFromWords(test.words))
+
+			for run := 0; run < 5; run++ {
+				if got := forestTags(NewWordGraphFromWords(test.words)); !reflect.DeepEqual(got, first) {
+					t.Fatalf("run %v gave different forest tags", run)
+				}
+			}
+
+			// The order words are added in doesn't matter either
+			if got := forestTags(NewWordGraphFromWords(reversed(test.words))); !reflect.DeepEqual(got, first) {
+				t.Errorf("adding the words backwards gave different forest tags")
+			}
+		})
+	}
+
+	// Smallest word first: fish's forest is numbered after card's
+	var tags = forestTags(NewWordGraphFromWords(fixtureColdWarm))
+	if tags["card"] != 1 || tags["dish"] != 2 {
+		t.Errorf("got tags %v for card and %v for dish, expected 1 and 2", tags["card"], tags["dish"])
+	}
+}
+
+func TestLengthLimits(t *testing.T) {
+	var list = "a\nat\ncat\ncold\nplant\nplane\nplace\nplanet\nplanets\n"
+
+	var tests = []struct {
+		name     string
+		min, max int
+		lengths  []int
+	}{
+		{"five only", 5, 5, []int{5}},
+		{"no limits", 0, 0, []int{1, 2, 3, 4, 5, 6, 7}},
+		{"at least five", 5, 0, []int{5, 6, 7}},
+		{"at most three", 0, 3, []int{1, 2, 3}},
+		{"nothing fits", 6, 5, []int{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.MinLength, g.MaxLength = test.min, test.max
+
+			if _, err := g.LoadFromReader(strings.NewReader(list)); err != nil {
+				t.Fatal(err)
+			}
+			g.ExploreForests()
+
+			var lengths = []int{}
+			for l := range g.Graphs {
+				lengths = append(lengths, l)
+			}
+			sort.Ints(lengths)
+
+			if !reflect.DeepEqual(lengths, test.lengths) {
+				t.Errorf("got subgraphs for lengths %v, expected %v", lengths, test.lengths)
+			}
+		})
+	}
+
+	// Only the five-letter words make it in, and they still make ladders
+	var g = NewWordGraph()
+	g.MinLength, g.MaxLength = 5, 5
+	g.LoadFromReader(strings.NewReader(list))
+
+	if got := g.GetTotalWords(); got != 3 {
+		t.Errorf("got %v words, expected 3", got)
+	}
+
+	if got := g.ShortestPath("plant", "place"); !samePath(got, []string{"plant", "plane", "place"}) {
+		t.Errorf("got %v", got)
+	}
+}
+
+func TestQueriesForMissingLength(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+
+	var tests = []struct {
+		s1, s2 string
+	}{
+		{"abcdefghijklmno", "abcdefghijklmnp"}, // No fifteen-letter words at all
+		{"cold", "cord"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if g.AreTwoWordsConnected(test.s1, test.s2) {
+			t.Errorf("AreTwoWordsConnected(%q, %q): got true", test.s1, test.s2)
+		}
+
+		if got := g.ShortestPath(test.s1, test.s2); got != nil {
+			t.Errorf("ShortestPath(%q, %q): got %v, expected nil", test.s1, test.s2, got)
+		}
+
+		if got := g.ConnectionStatus(test.s1, test.s2); got == Connected {
+			t.Errorf("ConnectionStatus(%q, %q): got %v", test.s1, test.s2, got)
+		}
+
+		if _, err := g.ShortestPathE(test.s1, test.s2); err == nil {
+			t.Errorf("ShortestPathE(%q, %q): expected an error", test.s1, test.s2)
+		}
+
+		if valid, _ := g.IsValidLadder([]string{test.s1, test.s2}); valid {
+			t.Errorf("IsValidLadder(%q, %q): got true", test.s1, test.s2)
+		}
+	}
+
+	// A zero graph has no subgraphs at all
+	var zero WordGraph
+	if zero.AreTwoWordsConnected("cat", "dog") || zero.ShortestPath("cat", "dog") != nil {
+		t.Errorf("a zero graph shouldn't connect anything")
+	}
+}
+
+func TestEmptyGraph(t *testing.T) {
+	var graphs = []struct {
+		name string
+		g    *WordGraph
+	}{
+		{"new", NewWordGraph()},
+		{"zero", &WordGraph{}},
+	}
+
+	for _, test := range graphs {
+		t.Run(test.name, func(t *testing.T) {
+			var g = test.g
+
+			g.ExploreForests()
+			if report := g.ExploreForestsTimed(); len(report.Lengths) != 0 || report.Duration < 0 {
+				t.Errorf("ExploreForestsTimed: got %+v", report)
+			}
+
+			if g.GetTotalWords() != 0 || g.GetTotalForests() != 0 || g.GetTotalDistinctWordLengths() != 0 {
+				t.Errorf("got %v words, %v forests, %v lengths", g.GetTotalWords(), g.GetTotalForests(), g.GetTotalDistinctWordLengths())
+			}
+
+			if stats := g.Stats(); stats.TotalWords != 0 || stats.TotalForests != 0 || stats.TotalEdges != 0 || len(stats.WordsByLength) != 0 {
+				t.Errorf("Stats: got %+v", stats)
+			}
+
+			if tag, size := g.LargestForest(3); tag != 0 || size != 0 {
+				t.Errorf("LargestForest: got %v, %v", tag, size)
+			}
+
+			if len(g.ForestSizeHistogram(3)) != 0 || len(g.ForestSizesByLength()) != 0 || len(g.IsolatedWords()) != 0 {
+				t.Errorf("expected no forests")
+			}
+
+			if g.AreTwoWordsConnected("cat", "dog") || g.ShortestPath("cat", "dog") != nil || g.ShortestPathEdit("cat", "dogs") != nil {
+				t.Errorf("expected no paths")
+			}
+
+			if _, err := g.ShortestPathE("cat", "dog"); err == nil {
+				t.Errorf("ShortestPathE: expected an error")
+			}
+
+			if steps, ok := g.LadderLength("cat", "dog"); ok || steps != 0 {
+				t.Errorf("LadderLength: got %v, %v", steps, ok)
+			}
+
+			if g.ConnectionStatus("cat", "dog") != WordMissing {
+				t.Errorf("ConnectionStatus: got %v", g.ConnectionStatus("cat", "dog"))
+			}
+
+			if valid, at := g.IsValidLadder([]string{"cat"}); valid || at != 0 {
+				t.Errorf("IsValidLadder: got %v, %v", valid, at)
+			}
+
+			if g.Contains("cat") || len(g.WordsInForestOf("cat")) != 0 {
+				t.Errorf("expected no words")
+			}
+
+			if _, ok := g.Neighbors("cat"); ok {
+				t.Errorf("Neighbors: cat shouldn't be there")
+			}
+
+			if _, ok := g.Degree("cat"); ok {
+				t.Errorf("Degree: cat shouldn't be there")
+			}
+
+			if _, _, ok := g.ForestTag("cat"); ok {
+				t.Errorf("ForestTag: cat shouldn't be there")
+			}
+
+			g.EachWord(func(word string) {
+				t.Errorf("EachWord: got %v", word)
+			})
+
+			if err := g.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+
+			if g.DictionaryHash() == "" || g.CacheKey() == "" {
+				t.Errorf("expected a hash and key even with no words")
+			}
+
+			var buf bytes.Buffer
+			if err := g.WriteJSON(&buf); err != nil {
+				t.Errorf("WriteJSON: %v", err)
+			}
+
+			if loaded, err := ReadJSON(&buf); err != nil || loaded.GetTotalWords() != 0 {
+				t.Errorf("ReadJSON: got %v, %v", loaded, err)
+			}
+
+			if err := g.WriteCSV(&buf); err != nil {
+				t.Errorf("WriteCSV: %v", err)
+			}
+
+			if err := g.SaveGzip(filepath.Join(t.TempDir(), "empty.json.gz")); err != nil {
+				t.Errorf("SaveGzip: %v", err)
+			}
+
+			if g.RemoveWord("cat") {
+				t.Errorf("RemoveWord: nothing to remove")
+			}
+
+			if g.Link("cat", "dog") == nil || g.Replace("cat", "cot") == nil {
+				t.Errorf("Link and Replace should fail with no words")
+			}
+
+			// And it still works once some words go in
+			g.AddWord("cat")
+			g.AddWord("cot")
+
+			if got := g.ShortestPath("cat", "cot"); !samePath(got, []string{"cat", "cot"}) {
+				t.Errorf("got %v after adding words", got)
+			}
+
+			// Neighbors are stored by default, so links work too
+			g.AddWord("dog")
+			g.ExploreForests()
+
+			if got := g.Graphs[3].WordGraph["cat"].Neighbors; !samePath(wordsOf(got), []string{"cot"}) {
+				t.Errorf("got stored neighbors %v, expected [cot]", wordsOf(got))
+			}
+
+			if err := g.Link("cat", "dog"); err != nil {
+				t.Errorf("Link: %v", err)
+			}
+		})
+	}
+}
+
+func TestExploreAgainWithNewRules(t *testing.T) {
+	var tests = []struct {
+		name   string
+		words  []string
+		change func(g *WordGraph)
+		s1, s2 string // Not connected before the change, connected after
+	}{
+		{"neighbor func", []string{"cat", "cot", "dog"}, func(g *WordGraph) {
+			g.NeighborFunc = func(a, b string) bool { return distance(a, b) <= 2 }
+		}, "cot", "dog"},
+		{"anagrams", []string{"team", "mate", "meat", "cold"}, func(g *WordGraph) {
+			g.Rules = EdgeRules{Anagrams: true}
+		}, "team", "mate"},
+		{"one way", []string{"cat", "cot", "pin"}, func(g *WordGraph) {
+			g.Directed = true
+		}, "", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words)
+
+			if test.s1 != "" && g.AreTwoWordsConnected(test.s1, test.s2) {
+				t.Fatalf("%v and %v shouldn't be connected yet", test.s1, test.s2)
+			}
+
+			test.change(g)
+
+			// The subgraphs haven't been told yet, so nothing's stale
+			if err := g.Validate(); err != nil {
+				t.Errorf("Validate before exploring again: %v", err)
+			}
+
+			g.ExploreForests()
+
+			if test.s1 != "" && !g.AreTwoWordsConnected(test.s1, test.s2) {
+				t.Errorf("%v and %v should be connected after exploring again", test.s1, test.s2)
+			}
+
+			if err := g.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+
+			// Exploring again from scratch gives the same graph
+			var fresh = NewWordGraph()
+			test.change(fresh)
+			for _, word := range test.words {
+				fresh.AddWord(word)
+			}
+			fresh.ExploreForests()
+
+			if got, want := forestTags(g), forestTags(fresh); !reflect.DeepEqual(got, want) {
+				t.Errorf("got forests %v, expected %v", got, want)
+			}
+
+			for _, word := range test.words {
+				got, _ := g.Neighbors(word)
+				want, _ := fresh.Neighbors(word)
+				if !samePath(got, want) {
+					t.Errorf("%v: got neighbors %v, expected %v", word, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateNoticesNewRules(t *testing.T) {
+	var g = NewWordGraphFromWords([]string{"team", "mate", "cold"})
+
+	g.Graphs[4].Rules = EdgeRules{Anagrams: true}
+	if err := g.Validate(); err == nil {
+		t.Errorf("Validate should notice the rules changed")
+	}
+
+	g.Graphs[4].ExploreAllForests()
+	if err := g.Validate(); err != nil {
+		t.Errorf("Validate after exploring again: %v", err)
+	}
+
+	// A reloaded graph remembers its rules, and keeps its links when explored again
+	g.Link("team", "cold")
+
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded.Rules = EdgeRules{Anagrams: true}
+	loaded.ExploreForests()
+
+	if err := loaded.Validate(); err != nil {
+		t.Errorf("Validate after reloading: %v", err)
+	}
+
+	if !loaded.AreTwoWordsConnected("mate", "cold") {
+		t.Errorf("the link to cold was lost reloading")
+	}
+}
+
+func TestNeighborFunc(t *testing.T) {
+	// Up to two letters can change in one step
+	var twoChanges = func(a, b string) bool {
+		return distance(a, b) <= 2
+	}
+
+	var tests = []struct {
+		name      string
+		neighbors func(a, b string) bool
+		useIndex  bool
+		forests   int
+		path      []string // cat to dog
+	}{
+		{"default", nil, false, 3, nil},
+		{"two changes", twoChanges, false, 2, []string{"cat", "cog", "dog"}},
+		{"two changes, index asked for", twoChanges, true, 2, []string{"cat", "cog", "dog"}}, // The index can't help, so it scans
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.NeighborFunc = test.neighbors
+			g.UseWildcardIndex = test.useIndex
+			for _, word := range []string{"cat", "cog", "dog", "xyz"} {
+				g.AddWord(word)
+			}
+			g.ExploreForests()
+
+			if got := g.GetTotalForests(); got != test.forests {
+				t.Errorf("got %v forests, expected %v", got, test.forests)
+			}
+
+			if got := g.ShortestPath("cat", "dog"); !samePath(got, test.path) {
+				t.Errorf("got %v, expected %v", got, test.path)
+			}
+
+			if got := g.Graphs[3].ShortestPathAStar("cat", "dog"); !samePath(got, test.path) {
+				t.Errorf("A*: got %v, expected %v", got, test.path)
+			}
+
+			if test.path != nil {
+				if valid, at := g.IsValidLadder(test.path); !valid {
+					t.Errorf("IsValidLadder(%v): failed at %v", test.path, at)
+				}
+			}
+
+			if err := g.Validate(); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+
+			if g.AreTwoWordsConnected("cat", "xyz") {
+				t.Errorf("xyz is three changes from everything, it should be on its own")
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		word string
+		want bool
+	}{
+		{"cat", true},
+		{"a", true},
+		{"fish", true},
+		{"cut", false},
+		{"Cat", false},
+		{"", false},
+		{"abcdefghijklmno", false}, // No words of that length at all
+	}
+
+	for _, test := range tests {
+		if got := g.Contains(test.word); got != test.want {
+			t.Errorf("Contains(%q): got %v, expected %v", test.word, got, test.want)
+		}
+	}
+
+	// Added words count straight away, before exploring
+	g.AddWord("cut")
+	if !g.Contains("cut") || !g.IsDirty() {
+		t.Errorf("cut should be there, waiting to be explored")
+	}
+}
+
+func TestAlphabet(t *testing.T) {
+	var list = "año\nano\naño\nano\nabo\nuno\nmañana\nçà\nAño\n"
+
+	var tests = []struct {
+		name     string
+		alphabet []rune
+		want     []string
+	}{
+		{"default", nil, []string{"abo", "ano", "año", "mañana", "uno", "çà"}}, // Any lowercase letter
+		{"spanish", []rune("abcdefghijklmnñopqrstuvwxyz"), []string{"abo", "ano", "año", "mañana", "uno"}},
+		{"a n ñ o", []rune("anño"), []string{"ano", "año"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.Alphabet = test.alphabet
+			g.LoadFromReader(strings.NewReader(list))
+			g.ExploreForests()
+
+			var got = []string{}
+			g.EachWord(func(word string) {
+				got = append(got, word)
+			})
+			sort.Strings(got)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("loaded %v, expected %v", got, test.want)
+			}
+
+			// Made-up words come from the alphabet too
+			if test.alphabet != nil {
+				var path = g.Graphs[3].ShortestPathWithBudget("ano", "año", 1)
+				for _, word := range path {
+					if !inAlphabet(word, test.alphabet) {
+						t.Errorf("%v steps through %v, which isn't spelled from the alphabet", path, word)
+					}
+				}
+			}
+		})
+	}
+
+	// ñ is a letter like any other, so a budget over an alphabet with ñ can make up words with it
+	var g = NewWordGraph()
+	g.Alphabet = []rune("ñop")
+	g.LoadFromReader(strings.NewReader("pop\nñoñ\n"))
+	g.ExploreForests()
+
+	if got := g.Graphs[3].ShortestPathWithBudget("pop", "ñoñ", 1); len(got) != 3 || !inAlphabet(got[1], g.Alphabet) {
+		t.Errorf("got %v, expected a step through ñop or poñ", got)
+	}
+}
@@ -0,0 +1,108 @@
+// Package httpapi serves a word ladder graph over HTTP.  It's kept out of package wordladder so that
+// programs that don't run a server don't link net/http.
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/cheilman/go-wordladder/wordladder"
+)
+
+// The answer to /ladder
+type ladderResponse struct {
+	Path      []string `json:"path"`
+	Steps     int      `json:"steps"`
+	Connected bool     `json:"connected"`
+}
+
+// The answer to /connected
+type connectedResponse struct {
+	Connected bool `json:"connected"`
+}
+
+// The answer to a bad request
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// An HTTP handler for solving ladders, for running the graph as a service:
+//
+//	GET /ladder?from=cat&to=dog     {"path": ["cat", "cot", "cog", "dog"], "steps": 3, "connected": true}
+//	GET /connected?from=cat&to=dog  {"connected": true}
+//
+// Words in different forests are still a 200, with an empty path.  Missing parameters, words of
+// different lengths, and words that aren't in the dictionary are a 400 with {"error": "..."}.
+// Requests are served concurrently, so Freeze the graph first.
+func Handler(g *wordladder.WordGraph) http.Handler {
+	var mux = http.NewServeMux()
+
+	mux.HandleFunc("/ladder", func(w http.ResponseWriter, r *http.Request) {
+		var from, to, ok = ladderQuery(w, r)
+		if !ok {
+			return
+		}
+
+		var path, err = g.ShortestPathE(from, to)
+		if err != nil && !errors.Is(err, wordladder.ErrNoPath) {
+			writeJSONResponse(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+
+		var retval = ladderResponse{Path: []string{}}
+		if len(path) > 0 {
+			retval = ladderResponse{Path: path, Steps: len(path) - 1, Connected: true}
+		}
+
+		writeJSONResponse(w, http.StatusOK, retval)
+	})
+
+	mux.HandleFunc("/connected", func(w http.ResponseWriter, r *http.Request) {
+		var from, to, ok = ladderQuery(w, r)
+		if !ok {
+			return
+		}
+
+		switch status := g.ConnectionStatus(from, to); status {
+		case wordladder.LengthMismatch:
+			writeJSONResponse(w, http.StatusBadRequest, errorResponse{Error: wordladder.ErrLengthMismatch.Error()})
+		case wordladder.WordMissing:
+			var missing = from
+			if g.Contains(from) {
+				missing = to
+			}
+
+			writeJSONResponse(w, http.StatusBadRequest, errorResponse{Error: wordladder.ErrWordNotFound{Word: missing}.Error()})
+		default:
+			writeJSONResponse(w, http.StatusOK, connectedResponse{Connected: status == wordladder.Connected})
+		}
+	})
+
+	return mux
+}
+
+// The from and to words of a request.  If they're missing (or it isn't a GET) the error has already
+// been written, and ok is false.
+func ladderQuery(w http.ResponseWriter, r *http.Request) (from string, to string, ok bool) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONResponse(w, http.StatusMethodNotAllowed, errorResponse{Error: "only GET is supported"})
+		return "", "", false
+	}
+
+	from, to = r.URL.Query().Get("from"), r.URL.Query().Get("to")
+	if from == "" || to == "" {
+		writeJSONResponse(w, http.StatusBadRequest, errorResponse{Error: "from and to are both required"})
+		return "", "", false
+	}
+
+	return from, to, true
+}
+
+// Write v as the JSON body of a response with the given status
+func writeJSONResponse(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
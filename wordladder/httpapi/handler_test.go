@@ -0,0 +1,79 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/cheilman/go-wordladder/wordladder"
+)
+
+func TestHandler(t *testing.T) {
+	var g = wordladder.NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "xyz", "cold"})
+	g.Freeze()
+
+	var server = httptest.NewServer(Handler(g))
+	defer server.Close()
+
+	var tests = []struct {
+		name   string
+		method string
+		url    string
+		status int
+		want   map[string]interface{}
+	}{
+		{"ladder", http.MethodGet, "/ladder?from=cat&to=dog", http.StatusOK,
+			map[string]interface{}{"path": []interface{}{"cat", "cot", "cog", "dog"}, "steps": 3.0, "connected": true}},
+		{"no ladder", http.MethodGet, "/ladder?from=cat&to=xyz", http.StatusOK,
+			map[string]interface{}{"path": []interface{}{}, "steps": 0.0, "connected": false}},
+		{"ladder length mismatch", http.MethodGet, "/ladder?from=cat&to=cold", http.StatusBadRequest, nil},
+		{"ladder missing word", http.MethodGet, "/ladder?from=cat&to=cut", http.StatusBadRequest, nil},
+		{"ladder missing parameter", http.MethodGet, "/ladder?from=cat", http.StatusBadRequest, nil},
+		{"connected", http.MethodGet, "/connected?from=cat&to=dog", http.StatusOK,
+			map[string]interface{}{"connected": true}},
+		{"not connected", http.MethodGet, "/connected?from=cat&to=xyz", http.StatusOK,
+			map[string]interface{}{"connected": false}},
+		{"connected length mismatch", http.MethodGet, "/connected?from=cat&to=cold", http.StatusBadRequest, nil},
+		{"connected missing word", http.MethodGet, "/connected?from=cut&to=cat", http.StatusBadRequest, nil},
+		{"not a GET", http.MethodPost, "/ladder?from=cat&to=dog", http.StatusMethodNotAllowed, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest(test.method, server.URL+test.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != test.status {
+				t.Errorf("got status %v, expected %v", resp.StatusCode, test.status)
+			}
+
+			if got := resp.Header.Get("Content-Type"); got != "application/json" {
+				t.Errorf("got content type %q", got)
+			}
+
+			var body map[string]interface{}
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.want == nil {
+				// Errors just need to say something
+				if msg, _ := body["error"].(string); msg == "" {
+					t.Errorf("expected an error message, got %v", body)
+				}
+			} else if !reflect.DeepEqual(body, test.want) {
+				t.Errorf("got %v, expected %v", body, test.want)
+			}
+		})
+	}
+}
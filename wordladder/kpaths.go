@@ -0,0 +1,150 @@
+package wordladder
+
+import (
+	"sort"
+	"strings"
+)
+
+// Return up to k distinct loopless paths from s1 to s2, shortest first.  Empty if no path exists.
+// Uses Yen's algorithm: each new path branches off an earlier one at some word (the spur), with the
+// earlier paths' next steps from that point blocked so the search has to find something different.
+func (g *WordGraphOfSameLength) KShortestPaths(s1 string, s2 string, k int) [][]string {
+	var retval = [][]string{}
+
+	if k <= 0 || !g.AreTwoWordsConnected(s1, s2) {
+		return retval
+	}
+
+	var first = g.shortestPathAllowing(s1, s2, nil)
+	if first == nil {
+		return retval
+	}
+	retval = append(retval, first)
+
+	var seen = map[string]bool{strings.Join(first, " "): true}
+	var candidates = [][]string{}
+
+	for len(retval) < k {
+		var prev = retval[len(retval)-1]
+
+		for i := 0; i < len(prev)-1; i++ {
+			var spur = prev[i]
+			var root = prev[:i+1]
+
+			// Block the next step of every path we already have that shares this root
+			var blockedSteps = make(map[[2]string]bool)
+			for _, path := range retval {
+				if len(path) > i+1 && samePath(path[:i+1], root) {
+					blockedSteps[[2]string{path[i], path[i+1]}] = true
+				}
+			}
+
+			// And the root itself (apart from the spur), so the result stays loopless
+			var blockedWords = make(map[string]bool)
+			for _, word := range root[:i] {
+				blockedWords[word] = true
+			}
+
+			var spurPath = g.shortestPathAllowing(spur, s2, func(from string, to string) bool {
+				return !blockedWords[to] && !blockedSteps[[2]string{from, to}]
+			})
+			if spurPath == nil {
+				continue
+			}
+
+			var path = append(append([]string{}, root[:i]...), spurPath...)
+			var key = strings.Join(path, " ")
+			if !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, path)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		// Shortest candidate next, earliest found among equals
+		sort.SliceStable(candidates, func(a, b int) bool {
+			return len(candidates[a]) < len(candidates[b])
+		})
+
+		retval = append(retval, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	return retval
+}
+
+// Whether two paths visit the same words in the same order
+func samePath(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Call yield with every loopless ladder from s1 to s2 that's at most maxLen words long, stopping
+// early if yield returns false.  Ladders come out in depth-first order, not shortest first, and each
+// is a fresh slice the caller can keep.  Nothing is called if either word is missing or they're in
+// different forests.  There can be a huge number of ladders on well connected forests, which is why
+// they're handed over one at a time instead of returned.
+func (g *WordGraphOfSameLength) EachLadder(s1 string, s2 string, maxLen int, yield func([]string) bool) {
+	if maxLen < 1 || !g.AreTwoWordsConnected(s1, s2) {
+		return
+	}
+
+	if s1 == s2 {
+		yield([]string{s1})
+		return
+	}
+
+	// Steps left to s2, ignoring direction, so branches that can't get there in time are skipped.
+	// Directed steps can only make the way longer, so this never skips a real ladder.
+	var toGoal map[string]int
+	if g.Directed {
+		toGoal = g.undirectedStepsFrom(s2)
+	} else {
+		toGoal = g.WordsWithinSteps(s2, maxLen-1)
+	}
+
+	var path = []string{s1}
+	var onPath = map[string]bool{s1: true}
+
+	var search func(word string) bool
+	search = func(word string) bool {
+		for _, neighborWord := range g.neighborsOf(g.node(word)) {
+			var steps, ok = toGoal[*neighborWord]
+			if !ok || onPath[*neighborWord] || len(path)+1+steps > maxLen {
+				continue
+			}
+
+			if *neighborWord == s2 {
+				if !yield(append(append([]string{}, path...), s2)) {
+					return false
+				}
+				continue
+			}
+
+			onPath[*neighborWord] = true
+			path = append(path, *neighborWord)
+
+			if !search(*neighborWord) {
+				return false
+			}
+
+			path = path[:len(path)-1]
+			onPath[*neighborWord] = false
+		}
+
+		return true
+	}
+	search(s1)
+}
@@ -0,0 +1,165 @@
+package wordladder
+
+import (
+	"math"
+	"sort"
+)
+
+// The dictionary word closest to word (fewest letter changes), and how many changes away it is.
+// Ties go alphabetically.  Handy for "did you mean" before calling ShortestPath.
+// ("", math.MaxInt32) if there are no words to pick from.
+func (g *WordGraphOfSameLength) NearestWord(word string) (string, int) {
+	var retval, best = "", math.MaxInt32
+
+	g.eachNode(func(v *WordNode) {
+		var d = distance(word, v.Word)
+
+		if d < best || (d == best && v.Word < retval) {
+			retval, best = v.Word, d
+		}
+	})
+
+	if retval == "" {
+		return "", math.MaxInt32
+	}
+
+	return retval, best
+}
+
+// The closest word (fewest letter changes) that's in a different forest from word, and how many
+// changes away it is.  Shows how near a forest is to another one: adding a word in between would
+// merge them.  Ties go alphabetically.  ("", math.MaxInt32) if word isn't in the graph or it's
+// the only forest.
+func (g *WordGraphOfSameLength) NearestOtherForest(word string) (other string, dist int) {
+	var node = g.node(word)
+	if node == nil {
+		return "", math.MaxInt32
+	}
+
+	other, dist = "", math.MaxInt32
+
+	g.eachNode(func(v *WordNode) {
+		if v.ForestTag == node.ForestTag {
+			return
+		}
+
+		var d = distance(word, v.Word)
+
+		if d < dist || (d == dist && v.Word < other) {
+			other, dist = v.Word, d
+		}
+	})
+
+	return other, dist
+}
+
+// The closest two words in different forests (fewest letter changes apart), alphabetically ordered,
+// and how many changes apart they are: a word in between would merge their forests.  Of equally close
+// pairs, the one joining the most words wins, then the first alphabetically.  ("", "", math.MaxInt32)
+// if there's only one forest.
+// Rather than compare every pair, this buckets the words by each way of blanking out one letter, then
+// two, and so on, and stops at the first count that puts two forests in the same bucket.
+func (g *WordGraphOfSameLength) ClosestCrossForestPair() (a, b string, dist int) {
+	a, b, dist = "", "", math.MaxInt32
+
+	var sizes = g.ForestSizes()
+	if len(sizes) < 2 {
+		return a, b, dist
+	}
+
+	// Alphabetical, so the first word of a forest in a bucket is its best choice there
+	var words = g.Words()
+
+	for k := 1; k <= g.WordLength; k++ {
+		// Pattern to forest tag to the first word from that forest
+		var buckets = make(map[string]map[int]string)
+
+		for _, word := range words {
+			var tag = g.node(word).ForestTag
+
+			for _, pattern := range maskedPatterns(word, k) {
+				if buckets[pattern] == nil {
+					buckets[pattern] = make(map[int]string)
+				}
+
+				if _, seen := buckets[pattern][tag]; !seen {
+					buckets[pattern][tag] = word
+				}
+			}
+		}
+
+		var bestSize = -1
+
+		for _, forests := range buckets {
+			for t1, w1 := range forests {
+				for t2, w2 := range forests {
+					if t1 >= t2 {
+						continue
+					}
+
+					var x, y = w1, w2
+					if y < x {
+						x, y = y, x
+					}
+
+					var size = sizes[t1] + sizes[t2]
+					if size > bestSize || (size == bestSize && (x < a || (x == a && y < b))) {
+						a, b, bestSize = x, y, size
+					}
+				}
+			}
+		}
+
+		if bestSize >= 0 {
+			// Nothing matched with fewer letters blanked, so these are exactly k apart
+			return a, b, k
+		}
+	}
+
+	return a, b, dist
+}
+
+// Every way of replacing k of word's letters with "_", e.g. "cat" with k = 2 gives "__t", "_a_" and "c__"
+func maskedPatterns(word string, k int) []string {
+	var letters = []rune(word)
+	var pattern = []rune(word)
+	var retval = []string{}
+
+	var mask func(from int, left int)
+	mask = func(from int, left int) {
+		if left == 0 {
+			retval = append(retval, string(pattern))
+			return
+		}
+
+		for i := from; i <= len(letters)-left; i++ {
+			pattern[i] = '_'
+			mask(i+1, left-1)
+			pattern[i] = letters[i]
+		}
+	}
+	mask(0, k)
+
+	return retval
+}
+
+// The n words closest to target (by distance), closest first, ties alphabetically
+func closestWords(words []*string, target string, n int) []*string {
+	var retval = make([]*string, len(words))
+	copy(retval, words)
+
+	sort.Slice(retval, func(i, j int) bool {
+		var di, dj = distance(*retval[i], target), distance(*retval[j], target)
+		if di != dj {
+			return di < dj
+		}
+
+		return *retval[i] < *retval[j]
+	})
+
+	if n < len(retval) {
+		retval = retval[:n]
+	}
+
+	return retval
+}
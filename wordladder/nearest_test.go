@@ -0,0 +1,108 @@
+package wordladder
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNearestWord(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureColdWarm).Graphs[4]
+
+	var tests = []struct {
+		word     string
+		want     string
+		distance int
+	}{
+		{"colf", "cold", 1}, // Typo for cold
+		{"fosh", "fish", 1},
+		{"worn", "word", 1}, // word and worm tie, word is first alphabetically
+		{"warm", "warm", 0},
+		{"zzzz", "card", 4},
+		{"cat", "", math.MaxInt32},
+	}
+
+	for _, test := range tests {
+		if got, d := g.NearestWord(test.word); got != test.want || d != test.distance {
+			t.Errorf("NearestWord(%v): got %v, %v, expected %v, %v", test.word, got, d, test.want, test.distance)
+		}
+	}
+
+	if got, d := NewWordGraphOfSameLength(4).NearestWord("cold"); got != "" || d != math.MaxInt32 {
+		t.Errorf("NearestWord with no words: got %v, %v", got, d)
+	}
+}
+
+func TestNearestOtherForest(t *testing.T) {
+	// Two forests, cat-cot and dog-dig, with cot and dog a word (cog or dot) away from joining up
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "dog", "dig"}).Graphs[3]
+	var single = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var tests = []struct {
+		g        *WordGraphOfSameLength
+		word     string
+		want     string
+		distance int
+	}{
+		{g, "cot", "dog", 2},
+		{g, "dog", "cot", 2},
+		{g, "cat", "dig", 3}, // dig and dog tie, dig is first alphabetically
+		{g, "dig", "cat", 3},
+		{g, "cut", "", math.MaxInt32},
+		{single, "cat", "", math.MaxInt32},
+	}
+
+	for _, test := range tests {
+		if got, d := test.g.NearestOtherForest(test.word); got != test.want || d != test.distance {
+			t.Errorf("NearestOtherForest(%v): got %v, %v, expected %v, %v", test.word, got, d, test.want, test.distance)
+		}
+	}
+
+	// Adding the word in between merges them, so there's nothing left to be near
+	g.AddWordAndLink("cog")
+	if got, d := g.NearestOtherForest("cot"); got != "" || d != math.MaxInt32 {
+		t.Errorf("after adding cog: got %v, %v", got, d)
+	}
+}
+
+func TestClosestCrossForestPair(t *testing.T) {
+	var tests = []struct {
+		name     string
+		words    []string
+		a, b     string
+		distance int
+	}{
+		// cot and dog are a substitution either side of cog (or dot), which would merge the forests
+		{"one word apart", []string{"cat", "cot", "dog", "dig"}, "cot", "dog", 2},
+		// pit is as close to cot as dog is, but joining dog's forest takes in more words
+		{"bigger forests win", []string{"cat", "cot", "dog", "dug", "pit"}, "cot", "dog", 2},
+		{"far apart", []string{"cat", "dog"}, "cat", "dog", 3},
+		{"one forest", fixtureCatDog, "", "", math.MaxInt32},
+		{"one word", []string{"cat"}, "", "", math.MaxInt32},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words).Graphs[3]
+
+			if a, b, d := g.ClosestCrossForestPair(); a != test.a || b != test.b || d != test.distance {
+				t.Errorf("got %v, %v, %v, expected %v, %v, %v", a, b, d, test.a, test.b, test.distance)
+			}
+		})
+	}
+
+	// The distance matches comparing every pair
+	var g = NewWordGraphFromWords(randomWords(300, 4, 96)).Graphs[4]
+	var words = g.Words()
+	var want = math.MaxInt32
+	for i, w1 := range words {
+		for _, w2 := range words[i+1:] {
+			if g.node(w1).ForestTag != g.node(w2).ForestTag {
+				want = min(want, distance(w1, w2))
+			}
+		}
+	}
+
+	if a, b, d := g.ClosestCrossForestPair(); d != want || distance(a, b) != d || g.node(a).ForestTag == g.node(b).ForestTag {
+		t.Errorf("got %v, %v, %v, expected a cross-forest pair %v apart", a, b, d, want)
+	}
+}
@@ -0,0 +1,91 @@
+package wordladder
+
+import (
+	"container/list"
+	"sync"
+)
+
+/**
+ * A least-recently-used cache of shortest paths, keyed on the (start, end) pair.
+ * Safe to use from several goroutines, since lookups on a frozen graph can come from anywhere.
+ */
+type pathCache struct {
+	size    int
+	entries map[[2]string]*list.Element
+	order   *list.List // most recently used at the front
+	lock    sync.Mutex
+}
+
+type pathCacheEntry struct {
+	key  [2]string
+	path []string
+}
+
+func newPathCache(size int) *pathCache {
+	return &pathCache{size: size, entries: make(map[[2]string]*list.Element), order: list.New()}
+}
+
+// The cached path from s1 to s2, and whether there was one.  A cached nil means there's no path.
+func (c *pathCache) get(s1 string, s2 string) ([]string, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var e = c.entries[[2]string{s1, s2}]
+	if e == nil {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+	return e.Value.(*pathCacheEntry).path, true
+}
+
+// Remember the path from s1 to s2, dropping the least recently used path if the cache is full
+func (c *pathCache) put(s1 string, s2 string, path []string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	var key = [2]string{s1, s2}
+
+	if e := c.entries[key]; e != nil {
+		e.Value.(*pathCacheEntry).path = path
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&pathCacheEntry{key: key, path: path})
+
+	if c.order.Len() > c.size {
+		var oldest = c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*pathCacheEntry).key)
+	}
+}
+
+// Forget everything
+func (c *pathCache) clear() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.entries = make(map[[2]string]*list.Element)
+	c.order.Init()
+}
+
+// Remember up to size ShortestPath results, so popular queries don't search again.  The cache is
+// emptied whenever the graph changes through its WordGraph methods (changing a subgraph directly
+// bypasses that, so don't mix the two with a cache).  Cached paths are shared between callers, so don't modify them.
+// A size of 0 or less turns the cache off.
+func (g *WordGraph) EnablePathCache(size int) {
+	if size <= 0 {
+		g.pathCache = nil
+		return
+	}
+
+	g.pathCache = newPathCache(size)
+}
+
+// Note that the graph has changed, so anything remembered about it is out of date
+func (g *WordGraph) changed() {
+	if g.pathCache != nil {
+		g.pathCache.clear()
+	}
+}
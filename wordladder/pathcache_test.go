@@ -0,0 +1,95 @@
+package wordladder
+
+import (
+	"testing"
+)
+
+func TestPathCacheEviction(t *testing.T) {
+	var c = newPathCache(2)
+
+	c.put("a", "b", []string{"a", "b"})
+	c.put("b", "c", []string{"b", "c"})
+	c.get("a", "b") // Now b -> c is the least recently used
+	c.put("c", "d", nil)
+
+	var tests = []struct {
+		s1, s2 string
+		cached bool
+	}{
+		{"a", "b", true},
+		{"b", "c", false},
+		{"c", "d", true},
+		{"b", "a", false}, // Keyed on the ordered pair
+	}
+
+	for _, test := range tests {
+		if _, cached := c.get(test.s1, test.s2); cached != test.cached {
+			t.Errorf("%v -> %v: got cached %v, expected %v", test.s1, test.s2, cached, test.cached)
+		}
+	}
+
+	c.clear()
+	if _, cached := c.get("a", "b"); cached {
+		t.Errorf("a -> b is still cached after clearing")
+	}
+}
+
+func TestPathCache(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+	g.EnablePathCache(10)
+
+	var expansions = 0
+	g.Graphs[3].expanded = func() { expansions++ }
+
+	var first = g.ShortestPath("cat", "dog")
+	if expansions == 0 {
+		t.Fatalf("the first query should search")
+	}
+
+	// The second comes from the cache, the very same slice, without searching
+	expansions = 0
+	var second = g.ShortestPath("cat", "dog")
+
+	if expansions != 0 {
+		t.Errorf("the second query expanded %v words", expansions)
+	}
+
+	if len(second) == 0 || &second[0] != &first[0] || !samePath(first, second) {
+		t.Errorf("got %v then %v, expected the cached slice", first, second)
+	}
+
+	// No path is cached too
+	g.ShortestPath("cat", "zzz")
+
+	// Changing the graph empties the cache
+	var changes = []struct {
+		name   string
+		change func()
+	}{
+		{"AddWord", func() { g.AddWord("cut") }},
+		{"RemoveWord", func() { g.RemoveWord("cut") }},
+	}
+
+	for _, change := range changes {
+		change.change()
+		g.ExploreForests()
+		g.Graphs[3].expanded = func() { expansions++ }
+
+		expansions = 0
+		g.ShortestPath("cat", "dog")
+
+		if expansions == 0 {
+			t.Errorf("after %v: the query came from the cache", change.name)
+		}
+	}
+
+	// Turning it off
+	g.EnablePathCache(0)
+	expansions = 0
+	g.ShortestPath("cat", "dog")
+	g.ShortestPath("cat", "dog")
+
+	if g.pathCache != nil || expansions == 0 {
+		t.Errorf("the cache should be off")
+	}
+}
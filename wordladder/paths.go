@@ -0,0 +1,618 @@
+package wordladder
+
+import (
+	"slices"
+)
+
+// Return every shortest path from s1 to s2.  Empty if no path exists.
+// The BFS stops at the layer where s1 is first reached, so only minimal-length paths are ever built.
+func (g *WordGraphOfSameLength) AllShortestPaths(s1 string, s2 string) [][]string {
+	var retval = [][]string{}
+
+	if !g.AreTwoWordsConnected(s1, s2) {
+		// No path exists
+		return retval
+	}
+
+	// Like ShortestPath, search backwards (s2 -> s1) so following parents from s1 walks towards s2.
+	// Directed edges can't be followed backwards, so then search forwards and reverse the paths.
+	var root, goal = s2, s1
+	if g.Directed {
+		root, goal = s1, s2
+	}
+
+	var depth = map[string]int{root: 0}
+	var parents = make(map[string][]string)
+	var layer = []string{root}
+	var found = s1 == s2
+
+	for !found && len(layer) > 0 {
+		var nextLayer = []string{}
+
+		for _, word := range layer {
+			for _, neighborWord := range g.neighborsOf(g.node(word)) {
+				var d, seen = depth[*neighborWord]
+
+				if !seen {
+					depth[*neighborWord] = depth[word] + 1
+					nextLayer = append(nextLayer, *neighborWord)
+				} else if d != depth[word]+1 {
+					// Reached it on an earlier layer, this isn't a shortest way in
+					continue
+				}
+
+				// Every parent one layer up is on some shortest path
+				parents[*neighborWord] = append(parents[*neighborWord], word)
+
+				if *neighborWord == goal {
+					found = true
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	if !found {
+		return retval
+	}
+
+	// Walk the parent links from the goal back to the root, branching wherever a word has several parents
+	var walk func(path []string)
+	walk = func(path []string) {
+		var last = path[len(path)-1]
+
+		if last == root {
+			var found = append([]string{}, path...)
+
+			if g.Directed {
+				slices.Reverse(found)
+			}
+
+			retval = append(retval, found)
+			return
+		}
+
+		for _, parent := range parents[last] {
+			walk(append(path, parent))
+		}
+	}
+	walk([]string{goal})
+
+	return retval
+}
+
+// Return a shortest path from s1 to s2 using A*.  Nil if no path exists.
+// Every step changes exactly one letter, so the hamming distance to s2 never overestimates the
+// steps remaining and the path found is as short as the BFS one, usually after exploring far fewer nodes.
+// Anagram moves, a custom NeighborFunc, and words joined with Link can change many letters at once, so
+// with any of those this falls back to plain BFS.
+func (g *WordGraphOfSameLength) ShortestPathAStar(s1 string, s2 string) []string {
+	if !g.AreTwoWordsConnected(s1, s2) {
+		// No path exists
+		return nil
+	}
+
+	if g.Rules.Anagrams || g.NeighborFunc != nil || g.hasCustomLinks {
+		// The heuristic could overestimate, so it's no longer safe
+		return g.ShortestPath(s1, s2)
+	}
+
+	var closed = make(map[string]bool)
+	var bestCost = map[string]int{s1: 0}
+	var target *WNPriorityQueueNode = nil
+
+	var q = WNPriorityQueue{}
+	q.push(&WNPriorityQueueNode{wn: g.node(s1), parent: nil, cost: 0, priority: distance(s1, s2)})
+
+	for {
+		var node = q.pop()
+
+		if node == nil {
+			return nil
+		}
+
+		// Skip stale entries for words we already reached more cheaply
+		if closed[node.wn.Word] {
+			continue
+		}
+		closed[node.wn.Word] = true
+
+		// Have we found our target word?
+		if node.wn.Word == s2 {
+			target = node
+			break
+		}
+
+		if g.expanded != nil {
+			g.expanded()
+		}
+
+		for _, neighborWord := range g.neighborsOf(node.wn) {
+			if closed[*neighborWord] {
+				continue
+			}
+
+			var cost = node.cost + 1
+			if best, seen := bestCost[*neighborWord]; seen && best <= cost {
+				continue
+			}
+			bestCost[*neighborWord] = cost
+
+			q.push(&WNPriorityQueueNode{
+				wn:       g.node(*neighborWord),
+				parent:   node,
+				cost:     cost,
+				priority: cost + distance(*neighborWord, s2),
+			})
+		}
+	}
+
+	// Build the path back up.  We searched forwards, so it comes out backwards.
+	var retval = []string{}
+
+	for cur := target; cur != nil; cur = cur.parent {
+		retval = append(retval, cur.wn.Word)
+	}
+
+	slices.Reverse(retval)
+
+	return retval
+}
+
+// Return the path from s1 to s2 with the lowest total cost, using Dijkstra.  Nil if no path exists.
+// Each step costs cost(from, to) summed over the letters it changes, so cheap swaps (say, keys next to
+// each other on a keyboard) can win over a path with fewer steps.  Costs mustn't be negative.
+// A nil cost makes every step cost 1, which gives a shortest path like ShortestPath.
+func (g *WordGraphOfSameLength) CheapestPath(s1 string, s2 string, cost func(from rune, to rune) float64) []string {
+	if !g.AreTwoWordsConnected(s1, s2) {
+		// No path exists
+		return nil
+	}
+
+	var closed = make(map[string]bool)
+	var bestCost = map[string]float64{s1: 0}
+	var target *WNCostQueueNode = nil
+
+	var q = WNCostQueue{}
+	q.push(&WNCostQueueNode{wn: g.node(s1), parent: nil, cost: 0})
+
+	for {
+		var node = q.pop()
+
+		if node == nil {
+			return nil
+		}
+
+		// Skip stale entries for words we already reached more cheaply
+		if closed[node.wn.Word] {
+			continue
+		}
+		closed[node.wn.Word] = true
+
+		// Have we found our target word?
+		if node.wn.Word == s2 {
+			target = node
+			break
+		}
+
+		for _, neighborWord := range g.neighborsOf(node.wn) {
+			if closed[*neighborWord] {
+				continue
+			}
+
+			var total = node.cost + stepCost(node.wn.Word, *neighborWord, cost)
+			if best, seen := bestCost[*neighborWord]; seen && best <= total {
+				continue
+			}
+			bestCost[*neighborWord] = total
+
+			q.push(&WNCostQueueNode{wn: g.node(*neighborWord), parent: node, cost: total})
+		}
+	}
+
+	// Build the path back up.  We searched forwards, so it comes out backwards.
+	var retval = []string{}
+
+	for cur := target; cur != nil; cur = cur.parent {
+		retval = append(retval, cur.wn.Word)
+	}
+
+	slices.Reverse(retval)
+
+	return retval
+}
+
+// The cost of one step from one word to another: cost summed over each changed letter, or 1 if cost is nil
+func stepCost(from string, to string, cost func(from rune, to rune) float64) float64 {
+	if cost == nil {
+		return 1
+	}
+
+	var retval = 0.0
+	var fromLetters = []rune(from)
+	var toLetters = []rune(to)
+
+	for i := range fromLetters {
+		if fromLetters[i] != toLetters[i] {
+			retval += cost(fromLetters[i], toLetters[i])
+		}
+	}
+
+	return retval
+}
+
+// Return a shortest path from s1 to s2 by searching from both ends at once.  Nil if no path exists.
+// Each side expands a whole BFS layer at a time (smaller frontier first) until the two meet, which
+// keeps the frontiers much smaller than a single search on big forests.  Directed graphs fall back
+// to ShortestPath.
+func (g *WordGraphOfSameLength) ShortestPathBidirectional(s1 string, s2 string) []string {
+	if !g.AreTwoWordsConnected(s1, s2) {
+		// No path exists
+		return nil
+	}
+
+	if g.Directed {
+		// The search from s2 would need to follow edges backwards
+		return g.ShortestPath(s1, s2)
+	}
+
+	if s1 == s2 {
+		return []string{s1}
+	}
+
+	// Parent links for each direction.  The start words are their own roots.
+	var fromStart = map[string]string{s1: ""}
+	var fromEnd = map[string]string{s2: ""}
+	var startLayer = []string{s1}
+	var endLayer = []string{s2}
+	var meeting = ""
+
+	for meeting == "" {
+		if len(startLayer) == 0 || len(endLayer) == 0 {
+			return nil
+		}
+
+		if len(startLayer) <= len(endLayer) {
+			startLayer, meeting = g.expandLayer(startLayer, fromStart, fromEnd)
+		} else {
+			endLayer, meeting = g.expandLayer(endLayer, fromEnd, fromStart)
+		}
+	}
+
+	// Walk back from the meeting word to s1, then reverse that half
+	var retval = []string{}
+
+	for cur := meeting; cur != ""; cur = fromStart[cur] {
+		retval = append(retval, cur)
+	}
+
+	slices.Reverse(retval)
+
+	// And then forward from the meeting word to s2
+	for cur := fromEnd[meeting]; cur != ""; cur = fromEnd[cur] {
+		retval = append(retval, cur)
+	}
+
+	return retval
+}
+
+// Expand one BFS layer for the bidirectional search, recording parents as we go.
+// Returns the next layer, and the first word also seen from the other side (or "" if none).
+func (g *WordGraphOfSameLength) expandLayer(layer []string, parents map[string]string, otherParents map[string]string) ([]string, string) {
+	var nextLayer = []string{}
+
+	for _, word := range layer {
+		for _, neighborWord := range g.neighborsOf(g.node(word)) {
+			if _, seen := parents[*neighborWord]; seen {
+				continue
+			}
+
+			parents[*neighborWord] = word
+
+			if _, seen := otherParents[*neighborWord]; seen {
+				return nextLayer, *neighborWord
+			}
+
+			nextLayer = append(nextLayer, *neighborWord)
+		}
+	}
+
+	return nextLayer, ""
+}
+
+// Return the shortest path to end from whichever of the candidate starts is closest, starting with that
+// candidate.  Handy when the word someone typed isn't in the dictionary and there are a few guesses at
+// what they meant.  Candidates that aren't in the graph are skipped; ties go to the earliest candidate.
+// Nil if none of them reach end.
+func (g *WordGraphOfSameLength) ShortestPathFromCandidates(starts []string, end string) []string {
+	if g.node(end) == nil {
+		return nil
+	}
+
+	// One BFS seeded with every start at once.  Starts are their own roots.
+	var parents = make(map[string]string)
+	var layer = []string{}
+
+	for _, start := range starts {
+		if _, seen := parents[start]; seen || g.node(start) == nil {
+			continue
+		}
+
+		parents[start] = ""
+		layer = append(layer, start)
+	}
+
+	for len(layer) > 0 {
+		var nextLayer = []string{}
+
+		for _, word := range layer {
+			if word == end {
+				// Walk back to whichever start we came from, then reverse
+				var retval = []string{}
+				for cur := word; cur != ""; cur = parents[cur] {
+					retval = append(retval, cur)
+				}
+
+				slices.Reverse(retval)
+
+				return retval
+			}
+
+			for _, neighborWord := range g.neighborsOf(g.node(word)) {
+				if _, seen := parents[*neighborWord]; !seen {
+					parents[*neighborWord] = word
+					nextLayer = append(nextLayer, *neighborWord)
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return nil
+}
+
+// Return a shortest path from s1 to s2 that may pass through up to invalidBudget words that aren't in
+// the dictionary, for relaxed puzzles.  Both ends have to be dictionary words.  Nil if there's no such path.
+// Words in the dictionary are only reached along the graph's own edges.  Made-up words have no stored
+// neighbors, so every step tries all the one-letter changes of the word (over the Alphabet) that the
+// NeighborFunc allows; each extra unit of budget makes the search much wider.
+func (g *WordGraphOfSameLength) ShortestPathWithBudget(s1 string, s2 string, invalidBudget int) []string {
+	if g.node(s1) == nil || g.node(s2) == nil || invalidBudget < 0 {
+		return nil
+	}
+
+	if invalidBudget == 0 {
+		return g.ShortestPath(s1, s2)
+	}
+
+	var alphabet = g.Alphabet
+	if alphabet == nil {
+		alphabet = DefaultAlphabet
+	}
+
+	// The search is over (word, budget used so far) pairs.  Reaching a word again is only worth it
+	// having used less of the budget than before.
+	type state struct {
+		word string
+		used int
+	}
+
+	var fewestUsed = map[string]int{s1: 0}
+	var parents = make(map[state]state)
+	var layer = []state{{s1, 0}}
+	var root = state{s1, 0}
+
+	for len(layer) > 0 {
+		var nextLayer = []state{}
+
+		for _, cur := range layer {
+			if cur.word == s2 {
+				// Walk back to s1, then reverse
+				var retval = []string{}
+				for at := cur; ; at = parents[at] {
+					retval = append(retval, at.word)
+					if at == root {
+						break
+					}
+				}
+
+				slices.Reverse(retval)
+
+				return retval
+			}
+
+			// From a word, its neighbors in the graph (so links, rules and direction all count), then
+			// made-up words.  From a made-up word there are no edges to follow, so any one-letter change
+			// the graph would take as a step.
+			var node = g.node(cur.word)
+			var next = []string{}
+
+			if node != nil {
+				for _, neighborWord := range g.neighborsOf(node) {
+					next = append(next, *neighborWord)
+				}
+			}
+
+			for _, candidate := range GenerateCandidatesFrom(cur.word, alphabet) {
+				if (node == nil || g.node(candidate) == nil) && g.isNeighbor(cur.word, candidate) {
+					next = append(next, candidate)
+				}
+			}
+
+			for _, word := range next {
+				var used = cur.used
+				if g.node(word) == nil {
+					used++
+				}
+
+				if used > invalidBudget {
+					continue
+				}
+
+				if fewest, seen := fewestUsed[word]; seen && fewest <= used {
+					continue
+				}
+				fewestUsed[word] = used
+
+				var n = state{word, used}
+				parents[n] = cur
+				nextLayer = append(nextLayer, n)
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return nil
+}
+
+// Return a shortest path from s1 to s2 that passes through via: the shortest path from s1 to via,
+// followed by the shortest path from via on to s2, with via only listed once.  The two halves are
+// found separately, so a word other than via can turn up in both.  Nil if either half has no path.
+func (g *WordGraphOfSameLength) ShortestPathVia(s1 string, via string, s2 string) []string {
+	var first = g.ShortestPath(s1, via)
+	if first == nil {
+		return nil
+	}
+
+	var second = g.ShortestPath(via, s2)
+	if second == nil {
+		return nil
+	}
+
+	return append(first, second[1:]...)
+}
+
+// Return a shortest path from s1 to s2 that never changes the letters at the frozen positions
+// (counted from 0), e.g. frozen = []int{0} keeps the first letter.  Nil if no such path exists.
+func (g *WordGraphOfSameLength) ShortestPathWithFrozen(s1 string, s2 string, frozen []int) []string {
+	if !g.AreTwoWordsConnected(s1, s2) || !sameLettersAt(s1, s2, frozen) {
+		// No path exists, or the ends differ somewhere we can't change
+		return nil
+	}
+
+	return g.shortestPathAllowing(s1, s2, func(from string, to string) bool {
+		return sameLettersAt(from, to, frozen)
+	})
+}
+
+// Plain BFS for a shortest path from s1 to s2, only taking the steps allowed(from, to) approves of
+// (nil allows everything).  Nil if there's no such path.
+func (g *WordGraphOfSameLength) shortestPathAllowing(s1 string, s2 string, allowed func(from string, to string) bool) []string {
+	var parents = map[string]string{s1: ""}
+	var layer = []string{s1}
+
+	for len(layer) > 0 {
+		var nextLayer = []string{}
+
+		for _, word := range layer {
+			if word == s2 {
+				// Walk back to s1, then reverse
+				var retval = []string{}
+				for cur := word; cur != ""; cur = parents[cur] {
+					retval = append(retval, cur)
+				}
+
+				slices.Reverse(retval)
+
+				return retval
+			}
+
+			for _, neighborWord := range g.neighborsOf(g.node(word)) {
+				if _, seen := parents[*neighborWord]; seen || (allowed != nil && !allowed(word, *neighborWord)) {
+					continue
+				}
+
+				parents[*neighborWord] = word
+				nextLayer = append(nextLayer, *neighborWord)
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return nil
+}
+
+// Number of steps in a shortest path from s1 to s2, and whether a path exists at all.
+// Cheaper than ShortestPath since it only tracks BFS depth, not parents.
+func (g *WordGraphOfSameLength) LadderLength(s1 string, s2 string) (int, bool) {
+	if !g.AreTwoWordsConnected(s1, s2) {
+		return 0, false
+	}
+
+	var visited = map[string]bool{s1: true}
+	var layer = []string{s1}
+
+	for steps := 0; len(layer) > 0; steps++ {
+		var nextLayer = []string{}
+
+		for _, word := range layer {
+			if word == s2 {
+				return steps, true
+			}
+
+			for _, neighborWord := range g.neighborsOf(g.node(word)) {
+				if !visited[*neighborWord] {
+					visited[*neighborWord] = true
+					nextLayer = append(nextLayer, *neighborWord)
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return 0, false
+}
+
+// Return a long ladder starting at start that never repeats a word, with at most maxLen words.
+// Nil if start isn't in the graph or maxLen is less than 1.
+// Finding the true longest simple path is NP-hard, so this is a depth-first search bounded by maxLen:
+// it tries every ladder up to that length and stops early once one reaches it.  The work grows
+// exponentially with maxLen on well connected forests, so keep it modest.  (Memoizing doesn't help:
+// the best way on from a word depends on which words the ladder has already used.)
+func (g *WordGraphOfSameLength) LongestSimpleLadder(start string, maxLen int) []string {
+	if g.node(start) == nil || maxLen < 1 {
+		return nil
+	}
+
+	var best = []string{start}
+	var path = []string{start}
+	var onPath = map[string]bool{start: true}
+
+	var search func(word string) bool
+	search = func(word string) bool {
+		if len(path) > len(best) {
+			best = append(best[:0:0], path...)
+		}
+
+		if len(path) == maxLen {
+			// Can't do any better than this
+			return true
+		}
+
+		for _, neighborWord := range g.neighborsOf(g.node(word)) {
+			if onPath[*neighborWord] {
+				continue
+			}
+
+			onPath[*neighborWord] = true
+			path = append(path, *neighborWord)
+
+			if search(*neighborWord) {
+				return true
+			}
+
+			path = path[:len(path)-1]
+			onPath[*neighborWord] = false
+		}
+
+		return false
+	}
+	search(start)
+
+	return best
+}
@@ -0,0 +1,585 @@
+package wordladder
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Paths as strings, sorted, so sets of paths can be compared
+func pathStrings(paths [][]string) []string {
+	var retval = make([]string, len(paths))
+	for i, path := range paths {
+		retval[i] = strings.Join(path, " ")
+	}
+	sort.Strings(retval)
+	return retval
+}
+
+func TestAllShortestPaths(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureColdWarm).Graphs[4]
+
+	var tests = []struct {
+		s1, s2 string
+		want   []string
+	}{
+		{"cold", "warm", []string{"cold cord card ward warm", "cold cord word ward warm", "cold cord word worm warm"}},
+		{"cold", "cord", []string{"cold cord"}},
+		{"cold", "cold", []string{"cold"}},
+		{"cold", "fish", []string{}},
+		{"cold", "nope", []string{}},
+	}
+
+	for _, test := range tests {
+		var paths = g.AllShortestPaths(test.s1, test.s2)
+
+		if paths == nil {
+			t.Errorf("%v -> %v: got nil, expected an empty list", test.s1, test.s2)
+		}
+
+		if got := pathStrings(paths); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v -> %v: got %v, expected %v", test.s1, test.s2, got, test.want)
+		}
+	}
+}
+
+func TestShortestPathAStarWithLinks(t *testing.T) {
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "hat"})
+	if err := g.Link("hat", "dog"); err != nil {
+		t.Fatal(err)
+	}
+
+	var want = []string{"cat", "hat", "dog"}
+
+	if got := g.ShortestPath("cat", "dog"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ShortestPath: got %v, expected %v", got, want)
+	}
+
+	// The link is three letters apart, so the hamming distance would overestimate
+	if got := g.Graphs[3].ShortestPathAStar("cat", "dog"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ShortestPathAStar: got %v, expected %v", got, want)
+	}
+}
+
+// A dense 4-letter subgraph and pairs of words in the same forest, for comparing searches
+func searchPairs(n int) (*WordGraphOfSameLength, [][2]string) {
+	var g = NewWordGraph()
+	g.UseWildcardIndex = true
+	for _, word := range randomWords(8000, 4, 5) {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	var subgraph = g.Graphs[4]
+	var words = subgraph.Words()
+	var r = rand.New(rand.NewSource(6))
+	var retval = [][2]string{}
+
+	for len(retval) < n {
+		var s1, s2 = words[r.Intn(len(words))], words[r.Intn(len(words))]
+		if s1 != s2 && subgraph.AreTwoWordsConnected(s1, s2) {
+			retval = append(retval, [2]string{s1, s2})
+		}
+	}
+
+	return subgraph, retval
+}
+
+func TestShortestPathAStar(t *testing.T) {
+	var g, pairs = searchPairs(50)
+	var bfsExpanded, astarExpanded int
+
+	for _, pair := range pairs {
+		g.expanded = func() { bfsExpanded++ }
+		var bfs = g.ShortestPath(pair[0], pair[1])
+
+		g.expanded = func() { astarExpanded++ }
+		var astar = g.ShortestPathAStar(pair[0], pair[1])
+
+		if len(astar) != len(bfs) || astar[0] != pair[0] || astar[len(astar)-1] != pair[1] {
+			t.Errorf("%v -> %v: got %v, expected a path as short as %v", pair[0], pair[1], astar, bfs)
+		}
+	}
+	g.expanded = nil
+
+	if astarExpanded >= bfsExpanded {
+		t.Errorf("A* expanded %v words, no fewer than BFS's %v", astarExpanded, bfsExpanded)
+	}
+}
+
+// Words expanded by BFS and A*, on the same pairs (run with -bench ShortestPathExpanded)
+func BenchmarkShortestPathExpanded(b *testing.B) {
+	var g, pairs = searchPairs(100)
+
+	for _, search := range []struct {
+		name string
+		fn   func(s1, s2 string) []string
+	}{
+		{"bfs", g.ShortestPath},
+		{"astar", g.ShortestPathAStar},
+	} {
+		b.Run(search.name, func(b *testing.B) {
+			var expanded = 0
+			g.expanded = func() { expanded++ }
+			defer func() { g.expanded = nil }()
+
+			for i := 0; i < b.N; i++ {
+				var pair = pairs[i%len(pairs)]
+				search.fn(pair[0], pair[1])
+			}
+
+			b.ReportMetric(float64(expanded)/float64(b.N), "nodes/op")
+		})
+	}
+}
+
+func TestShortestPathBidirectional(t *testing.T) {
+	var g, pairs = searchPairs(50)
+
+	// Plus some easy ones
+	pairs = append(pairs, [2]string{pairs[0][0], pairs[0][0]})
+
+	for _, pair := range pairs {
+		var bfs = g.ShortestPath(pair[0], pair[1])
+		var both = g.ShortestPathBidirectional(pair[0], pair[1])
+
+		if len(both) != len(bfs) || both[0] != pair[0] || both[len(both)-1] != pair[1] {
+			t.Errorf("%v -> %v: got %v, expected a path as short as %v", pair[0], pair[1], both, bfs)
+			continue
+		}
+
+		for i := 1; i < len(both); i++ {
+			if !areNeighbors(both[i-1], both[i]) {
+				t.Errorf("%v -> %v: %v jumps from %v to %v", pair[0], pair[1], both, both[i-1], both[i])
+			}
+		}
+	}
+
+	var cold = NewWordGraphFromWords(fixtureColdWarm).Graphs[4]
+	if got := cold.ShortestPathBidirectional("cold", "fish"); got != nil {
+		t.Errorf("got %v for words in different forests, expected nil", got)
+	}
+}
+
+func TestShortestPathLimited(t *testing.T) {
+	// cot has four neighbors: cat, cog, dot and hot
+	var tests = []struct {
+		limit     int
+		want      []string
+		truncated bool
+	}{
+		{0, []string{"cat", "cot", "cog"}, false},
+		{4, []string{"cat", "cot", "cog"}, false},
+		{3, []string{"cat", "cot", "cog"}, true},
+		{1, []string{"cat", "cot", "cog"}, true}, // cat looks closest to the target, so it's kept
+	}
+
+	for _, test := range tests {
+		var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+		g.MaxNeighborsExplored = test.limit
+
+		var got, truncated = g.ShortestPathLimited("cat", "cog")
+		if !samePath(got, test.want) || truncated != test.truncated {
+			t.Errorf("limit %v: got %v (truncated %v), expected %v (truncated %v)", test.limit, got, truncated, test.want, test.truncated)
+		}
+
+		if plain := g.ShortestPath("cat", "cog"); !samePath(plain, got) {
+			t.Errorf("limit %v: ShortestPath got %v, ShortestPathLimited got %v", test.limit, plain, got)
+		}
+	}
+}
+
+func TestKShortestPaths(t *testing.T) {
+	// cat-cot-cog and cat-cag-cog make a diamond
+	var diamond = NewWordGraphFromWords([]string{"cat", "cot", "cag", "cog"}).Graphs[3]
+	var coldWarm = NewWordGraphFromWords(fixtureColdWarm).Graphs[4]
+
+	var tests = []struct {
+		g      *WordGraphOfSameLength
+		s1, s2 string
+		k      int
+		want   []string
+	}{
+		{diamond, "cat", "cog", 2, []string{"cat cag cog", "cat cot cog"}},
+		{diamond, "cat", "cog", 5, []string{"cat cag cog", "cat cot cog"}},
+		{diamond, "cat", "cog", 0, []string{}},
+		{diamond, "cat", "dog", 2, []string{}},
+		{coldWarm, "cold", "warm", 3, []string{"cold cord card ward warm", "cold cord word ward warm", "cold cord word worm warm"}},
+		{coldWarm, "cold", "warm", 4, []string{"cold cord card ward warm", "cold cord card ward word worm warm", "cold cord word ward warm", "cold cord word worm warm"}},
+		{coldWarm, "cold", "fish", 3, []string{}},
+	}
+
+	for _, test := range tests {
+		var paths = test.g.KShortestPaths(test.s1, test.s2, test.k)
+
+		if got := pathStrings(paths); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v -> %v, k = %v: got %v, expected %v", test.s1, test.s2, test.k, got, test.want)
+		}
+
+		for i := 1; i < len(paths); i++ {
+			if len(paths[i]) < len(paths[i-1]) {
+				t.Errorf("%v -> %v, k = %v: %v comes after the longer %v", test.s1, test.s2, test.k, paths[i], paths[i-1])
+			}
+		}
+	}
+
+	// Just one of the diamond's sides when only one is asked for
+	if got := diamond.KShortestPaths("cat", "cog", 1); len(got) != 1 || len(got[0]) != 3 {
+		t.Errorf("k = 1: got %v", got)
+	}
+}
+
+func TestPathOrientation(t *testing.T) {
+	var subgraph = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var cached = NewWordGraphFromWords(fixtureCatDog)
+	cached.EnablePathCache(10)
+
+	var directed = NewWordGraph()
+	directed.Directed = true
+	for _, word := range fixtureCatDog {
+		directed.AddWord(word)
+	}
+	directed.ExploreForests()
+
+	var searches = []struct {
+		name string
+		find func(s1, s2 string) []string
+	}{
+		{"ShortestPath", subgraph.ShortestPath},
+		{"ShortestPathAStar", subgraph.ShortestPathAStar},
+		{"ShortestPathBidirectional", subgraph.ShortestPathBidirectional},
+		{"directed", directed.ShortestPath},
+		{"cached", cached.ShortestPath},
+	}
+
+	var pairs = [][2]string{{"cat", "dog"}, {"dog", "cat"}, {"bat", "cog"}, {"cog", "bat"}, {"hot", "cot"}}
+
+	for _, search := range searches {
+		t.Run(search.name, func(t *testing.T) {
+			// Twice over, so the second round comes from the cache for "cached"
+			for round := 0; round < 2; round++ {
+				for _, pair := range pairs {
+					var result = search.find(pair[0], pair[1])
+
+					if len(result) == 0 || result[0] != pair[0] || result[len(result)-1] != pair[1] {
+						t.Errorf("%v -> %v: got %v, expected it to start with %v and end with %v", pair[0], pair[1], result, pair[0], pair[1])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestShortestPathWithFrozen(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var tests = []struct {
+		s1, s2 string
+		frozen []int
+		want   []string
+	}{
+		{"cat", "cog", nil, []string{"cat", "cot", "cog"}},
+		{"cat", "dog", []int{2}, nil}, // t can't become g with the last letter frozen
+		{"bat", "cot", []int{2}, []string{"bat", "cat", "cot"}},
+		{"cat", "cog", []int{0}, []string{"cat", "cot", "cog"}},
+		{"cat", "cog", []int{2}, nil},
+		{"cat", "cot", []int{0, 2}, []string{"cat", "cot"}},
+		{"bat", "hot", []int{0}, nil},
+		{"cat", "cat", []int{0, 1, 2}, []string{"cat"}},
+		{"cat", "cot", []int{9}, []string{"cat", "cot"}}, // Past the end, so ignored
+	}
+
+	for _, test := range tests {
+		if got := g.ShortestPathWithFrozen(test.s1, test.s2, test.frozen); !samePath(got, test.want) {
+			t.Errorf("%v -> %v frozen at %v: got %v, expected %v", test.s1, test.s2, test.frozen, got, test.want)
+		}
+	}
+}
+
+func TestCheapestPath(t *testing.T) {
+	var g = NewWordGraphFromWords([]string{"aaa", "aab", "abb", "aac", "acc", "abc"}).Graphs[3]
+
+	// Turning an a into a b is dear, everything else is cheap
+	var dearAToB = func(from rune, to rune) float64 {
+		if from == 'a' && to == 'b' {
+			return 10
+		}
+		return 1
+	}
+
+	var tests = []struct {
+		name   string
+		s1, s2 string
+		cost   func(rune, rune) float64
+		want   []string
+	}{
+		{"nil cost is fewest steps", "aaa", "abb", nil, []string{"aaa", "aab", "abb"}},
+		{"longer but cheaper", "aaa", "abb", dearAToB, []string{"aaa", "aac", "acc", "abc", "abb"}},
+		{"same word", "aaa", "aaa", dearAToB, []string{"aaa"}},
+		{"unknown word", "aaa", "zzz", dearAToB, nil},
+	}
+
+	for _, test := range tests {
+		if got := g.CheapestPath(test.s1, test.s2, test.cost); !samePath(got, test.want) {
+			t.Errorf("%v: got %v, expected %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestLongestSimpleLadder(t *testing.T) {
+	// A plain chain, cat-cot-cog-dog-dig
+	var chain = NewWordGraphFromWords([]string{"cat", "cot", "cog", "dog", "dig"}).Graphs[3]
+
+	var tests = []struct {
+		start  string
+		maxLen int
+		want   []string
+	}{
+		{"cat", 10, []string{"cat", "cot", "cog", "dog", "dig"}},
+		{"dig", 5, []string{"dig", "dog", "cog", "cot", "cat"}},
+		{"cat", 3, []string{"cat", "cot", "cog"}},
+		{"cat", 1, []string{"cat"}},
+		{"cat", 0, nil},
+		{"cut", 5, nil},
+	}
+
+	for _, test := range tests {
+		if got := chain.LongestSimpleLadder(test.start, test.maxLen); !samePath(got, test.want) {
+			t.Errorf("LongestSimpleLadder(%v, %v): got %v, expected %v", test.start, test.maxLen, got, test.want)
+		}
+	}
+
+	// From the middle, either way is as long as the other
+	if got := chain.LongestSimpleLadder("cog", 10); len(got) != 3 || got[0] != "cog" {
+		t.Errorf("LongestSimpleLadder(cog, 10): got %v", got)
+	}
+
+	// Loops in the graph mustn't show up as repeats in the ladder
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+	var ladder = g.LongestSimpleLadder("bat", 8)
+	var seen = make(map[string]bool)
+
+	for i, word := range ladder {
+		if seen[word] {
+			t.Errorf("%v repeats %v", ladder, word)
+		}
+		seen[word] = true
+
+		if i > 0 && !areNeighbors(ladder[i-1], word) {
+			t.Errorf("%v jumps from %v to %v", ladder, ladder[i-1], word)
+		}
+	}
+
+	if len(ladder) != 8 {
+		t.Errorf("got %v, expected a ladder through all 8 words", ladder)
+	}
+}
+
+func TestShortestPathFromCandidates(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var tests = []struct {
+		starts []string
+		end    string
+		want   []string
+	}{
+		{[]string{"bat", "hat"}, "dog", []string{"hat", "hot", "dot", "dog"}}, // bat is a step further
+		{[]string{"bat", "dot"}, "dog", []string{"dot", "dog"}},
+		{[]string{"cog", "dot"}, "dog", []string{"cog", "dog"}}, // A tie, so the first one
+		{[]string{"dot", "cog"}, "dog", []string{"dot", "dog"}},
+		{[]string{"cut", "cog"}, "dog", []string{"cog", "dog"}},
+		{[]string{"dog", "cog"}, "dog", []string{"dog"}},
+		{[]string{"cut", "dug"}, "dog", nil},
+		{[]string{"cat"}, "dug", nil},
+		{[]string{}, "dog", nil},
+	}
+
+	for _, test := range tests {
+		if got := g.ShortestPathFromCandidates(test.starts, test.end); !samePath(got, test.want) {
+			t.Errorf("%v -> %v: got %v, expected %v", test.starts, test.end, got, test.want)
+		}
+	}
+}
+
+func TestShortestPathWithBudget(t *testing.T) {
+	// Two forests, cat-cot and dog-dig.  cog or dot would join them, but neither is a word here.
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "dog", "dig", "fig"}).Graphs[3]
+
+	var tests = []struct {
+		s1, s2  string
+		budget  int
+		steps   int // -1 for no path
+		invalid int // most made-up words along the way
+	}{
+		{"cat", "dog", 0, -1, 0},
+		{"cat", "dog", 1, 3, 1},
+		{"cat", "dog", 2, 3, 2}, // No shorter for the extra budget, though it may be spent
+		{"cat", "cot", 1, 1, 0},
+		{"cat", "fig", 1, 5, 1},  // Over to the other forest and along it
+		{"cat", "fig", 2, 3, 2},  // Straight there, through two made-up words
+		{"cat", "cut", 3, -1, 0}, // The ends have to be words
+		{"cat", "dog", -1, -1, 0},
+	}
+
+	for _, test := range tests {
+		var path = g.ShortestPathWithBudget(test.s1, test.s2, test.budget)
+
+		if test.steps < 0 {
+			if path != nil {
+				t.Errorf("%v -> %v, budget %v: got %v, expected nil", test.s1, test.s2, test.budget, path)
+			}
+			continue
+		}
+
+		if len(path) != test.steps+1 || path[0] != test.s1 || path[len(path)-1] != test.s2 {
+			t.Errorf("%v -> %v, budget %v: got %v, expected %v steps", test.s1, test.s2, test.budget, path, test.steps)
+			continue
+		}
+
+		var invalid = 0
+		for i, word := range path {
+			if g.node(word) == nil {
+				invalid++
+			}
+
+			if i > 0 && !areNeighbors(path[i-1], word) {
+				t.Errorf("%v jumps from %v to %v", path, path[i-1], word)
+			}
+		}
+
+		if invalid > test.invalid {
+			t.Errorf("%v -> %v, budget %v: %v has %v made-up words, expected at most %v", test.s1, test.s2, test.budget, path, invalid, test.invalid)
+		}
+	}
+}
+
+func TestShortestPathWithBudgetNeighborFunc(t *testing.T) {
+	// Only the first letter may change, so cat and cot aren't neighbors
+	var g = NewWordGraph()
+	g.NeighborFunc = func(a, b string) bool {
+		return a[1:] == b[1:]
+	}
+	for _, word := range []string{"bat", "cat", "cot", "dot"} {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	var subgraph = g.Graphs[3]
+
+	var tests = []struct {
+		s1, s2 string
+		budget int
+		path   []string
+	}{
+		{"cat", "bat", 1, []string{"cat", "bat"}},
+		{"cat", "cot", 1, nil}, // Not a step, and there's no made-up word to go through either
+		{"cat", "cot", 5, nil},
+		{"cot", "dot", 1, []string{"cot", "dot"}},
+		{"bat", "dot", 2, nil},
+	}
+
+	for _, test := range tests {
+		var got = subgraph.ShortestPathWithBudget(test.s1, test.s2, test.budget)
+		if !samePath(got, test.path) {
+			t.Errorf("%v -> %v, budget %v: got %v, expected %v", test.s1, test.s2, test.budget, got, test.path)
+		}
+
+		if got == nil && subgraph.AreTwoWordsConnected(test.s1, test.s2) {
+			t.Errorf("%v -> %v: connected, but no path with a budget", test.s1, test.s2)
+		}
+	}
+}
+
+func TestEachLadder(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureColdWarm).Graphs[4]
+
+	var tests = []struct {
+		s1, s2 string
+		maxLen int
+		want   []string
+	}{
+		{"cold", "warm", 4, []string{}},
+		{"cold", "warm", 5, []string{"cold cord card ward warm", "cold cord word ward warm", "cold cord word worm warm"}},
+		{"cold", "warm", 6, []string{"cold cord card ward warm", "cold cord word ward warm", "cold cord word worm warm"}},
+		{"cold", "warm", 100, []string{"cold cord card ward warm", "cold cord card ward word worm warm", "cold cord word ward warm", "cold cord word worm warm"}},
+		{"cold", "cord", 100, []string{"cold cord"}},
+		{"cold", "cold", 1, []string{"cold"}},
+		{"cold", "cold", 0, []string{}},
+		{"cold", "fish", 100, []string{}},
+	}
+
+	for _, test := range tests {
+		var ladders = [][]string{}
+		g.EachLadder(test.s1, test.s2, test.maxLen, func(ladder []string) bool {
+			ladders = append(ladders, ladder)
+			return true
+		})
+
+		if got := pathStrings(ladders); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v -> %v, up to %v words: got %v, expected %v", test.s1, test.s2, test.maxLen, got, test.want)
+		}
+	}
+
+	// Stopping after the second one
+	var calls = 0
+	g.EachLadder("cold", "warm", 100, func(ladder []string) bool {
+		calls++
+		return calls < 2
+	})
+
+	if calls != 2 {
+		t.Errorf("got %v calls, expected it to stop after 2", calls)
+	}
+}
+
+func TestShortestPathVia(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var tests = []struct {
+		s1, via, s2 string
+		steps       int // -1 for no path
+	}{
+		{"cat", "cog", "dog", 3}, // On a shortest path anyway
+		{"cat", "bat", "dog", 5}, // bat is a step back
+		{"cat", "hot", "cog", 4},
+		{"cat", "cat", "dog", 3},
+		{"cat", "dog", "dog", 3},
+		{"cat", "cut", "dog", -1},
+	}
+
+	for _, test := range tests {
+		var path = g.ShortestPathVia(test.s1, test.via, test.s2)
+
+		if test.steps < 0 {
+			if path != nil {
+				t.Errorf("%v -> %v -> %v: got %v, expected nil", test.s1, test.via, test.s2, path)
+			}
+			continue
+		}
+
+		if len(path) != test.steps+1 || path[0] != test.s1 || path[len(path)-1] != test.s2 || !slices.Contains(path, test.via) {
+			t.Errorf("%v -> %v -> %v: got %v, expected %v steps through %v", test.s1, test.via, test.s2, path, test.steps, test.via)
+			continue
+		}
+
+		// No shorter than either leg, and the waypoint only once
+		var first, second = g.ShortestPath(test.s1, test.via), g.ShortestPath(test.via, test.s2)
+		if len(path) < len(first) || len(path) < len(second) || len(path) != len(first)+len(second)-1 {
+			t.Errorf("%v -> %v -> %v: got %v from legs %v and %v", test.s1, test.via, test.s2, path, first, second)
+		}
+
+		if valid, at := NewWordGraphFromWords(fixtureCatDog).IsValidLadder(path); !valid {
+			t.Errorf("%v isn't a ladder, at %v", path, at)
+		}
+	}
+
+	// Disconnected legs
+	var split = NewWordGraphFromWords(fixtureMixed).Graphs[3]
+	if got := split.ShortestPathVia("cat", "dog", "cot"); got != nil {
+		t.Errorf("got %v through a word in another forest", got)
+	}
+}
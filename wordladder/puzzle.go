@@ -0,0 +1,86 @@
+package wordladder
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// Pick a random solvable puzzle: two words of the given length at least minSteps apart, and a
+// shortest ladder between them.  The same seed on the same graph gives the same puzzle.
+// Empty if length doesn't match this subgraph or no two words are that far apart.
+func (g *WordGraphOfSameLength) RandomLadder(length int, minSteps int, rng *rand.Rand) (start, end string, path []string) {
+	if length != g.WordLength {
+		return "", "", nil
+	}
+
+	// Sorted first, since map order would make the seed meaningless
+	var words = make([]string, 0, g.GetTotalWords())
+	g.eachNode(func(v *WordNode) {
+		words = append(words, v.Word)
+	})
+	sort.Strings(words)
+
+	// Try starts in random order until one has a far enough word
+	for _, i := range rng.Perm(len(words)) {
+		start = words[i]
+
+		var candidates = []string{}
+		for word, steps := range g.stepsFrom(start) {
+			if steps >= minSteps && word != start {
+				candidates = append(candidates, word)
+			}
+		}
+
+		if len(candidates) == 0 {
+			continue
+		}
+
+		sort.Strings(candidates)
+		end = candidates[rng.Intn(len(candidates))]
+
+		if g.AreTwoWordsConnected(start, end) {
+			return start, end, g.ShortestPath(start, end)
+		}
+	}
+
+	return "", "", nil
+}
+
+// Pick up to perForest random words from each forest with between minSize and maxSize words (inclusive),
+// keyed by forest tag.  The same seed on the same graph gives the same sample.
+func (g *WordGraphOfSameLength) SampleForestsBySize(minSize int, maxSize int, perForest int, rng *rand.Rand) map[int][]string {
+	var retval = make(map[int][]string)
+
+	// Group the words by forest in one pass, rather than a scan per forest
+	var members = make(map[int][]string)
+	g.eachNode(func(v *WordNode) {
+		members[v.ForestTag] = append(members[v.ForestTag], v.Word)
+	})
+
+	// Visit forests in tag order, since map order would make the seed meaningless
+	var tags = make([]int, 0, len(members))
+	for tag, words := range members {
+		if tag > 0 && len(words) >= minSize && len(words) <= maxSize {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Ints(tags)
+
+	for _, tag := range tags {
+		var words = members[tag]
+		sort.Strings(words)
+
+		var sample = []string{}
+		for _, i := range rng.Perm(len(words)) {
+			if len(sample) >= perForest {
+				break
+			}
+
+			sample = append(sample, words[i])
+		}
+
+		retval[tag] = sample
+	}
+
+	return retval
+}
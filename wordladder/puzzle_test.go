@@ -0,0 +1,109 @@
+package wordladder
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRandomLadder(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var tests = []struct {
+		name     string
+		length   int
+		minSteps int
+		solvable bool
+	}{
+		{"one step", 3, 1, true},
+		{"three steps", 3, 3, true}, // bat-dog, cat-dog and the like
+		{"too far", 3, 5, false},
+		{"wrong length", 4, 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for seed := int64(0); seed < 20; seed++ {
+				var start, end, path = g.RandomLadder(test.length, test.minSteps, rand.New(rand.NewSource(seed)))
+
+				if !test.solvable {
+					if start != "" || end != "" || path != nil {
+						t.Fatalf("seed %v: got %v -> %v (%v), expected nothing", seed, start, end, path)
+					}
+					continue
+				}
+
+				if !g.AreTwoWordsConnected(start, end) {
+					t.Fatalf("seed %v: %v and %v aren't connected", seed, start, end)
+				}
+
+				if len(path)-1 < test.minSteps || path[0] != start || path[len(path)-1] != end {
+					t.Fatalf("seed %v: got path %v for %v -> %v, expected at least %v steps", seed, path, start, end, test.minSteps)
+				}
+
+				// The same seed again gives the same puzzle
+				var start2, end2, path2 = g.RandomLadder(test.length, test.minSteps, rand.New(rand.NewSource(seed)))
+				if start2 != start || end2 != end || !reflect.DeepEqual(path2, path) {
+					t.Fatalf("seed %v: got %v -> %v then %v -> %v", seed, start, end, start2, end2)
+				}
+			}
+		})
+	}
+}
+
+func TestSampleForestsBySize(t *testing.T) {
+	// Forests of 7 (cold ... worm), 2 (dish fish), 1 (zzzz)
+	var g = NewWordGraphFromWords(append([]string{"zzzz"}, fixtureColdWarm...)).Graphs[4]
+	var sizes = g.ForestSizes()
+
+	var tests = []struct {
+		name      string
+		min, max  int
+		perForest int
+		forests   int
+		sampled   int // words sampled across every forest
+	}{
+		{"everything", 1, 100, 100, 3, 10},
+		{"pairs and up", 2, 100, 1, 2, 2},
+		{"middling", 2, 6, 5, 1, 2},
+		{"singletons", 1, 1, 3, 1, 1},
+		{"none that big", 8, 100, 3, 0, 0},
+		{"three each", 1, 100, 3, 3, 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var sample = g.SampleForestsBySize(test.min, test.max, test.perForest, rand.New(rand.NewSource(1)))
+
+			if len(sample) != test.forests {
+				t.Errorf("got %v forests, expected %v", len(sample), test.forests)
+			}
+
+			var sampled = 0
+			for tag, words := range sample {
+				if sizes[tag] < test.min || sizes[tag] > test.max {
+					t.Errorf("forest %v has %v words, outside %v-%v", tag, sizes[tag], test.min, test.max)
+				}
+
+				var seen = make(map[string]bool)
+				for _, word := range words {
+					if g.node(word) == nil || g.node(word).ForestTag != tag || seen[word] {
+						t.Errorf("%v shouldn't be in forest %v's sample %v", word, tag, words)
+					}
+					seen[word] = true
+				}
+
+				sampled += len(words)
+			}
+
+			if sampled != test.sampled {
+				t.Errorf("got %v words, expected %v", sampled, test.sampled)
+			}
+
+			// The same seed again gives the same sample
+			if again := g.SampleForestsBySize(test.min, test.max, test.perForest, rand.New(rand.NewSource(1))); !reflect.DeepEqual(again, sample) {
+				t.Errorf("got %v then %v from the same seed", sample, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package wordladder
+
+import (
+	"container/heap"
+)
+
+// A queue of word nodes with pathing information.  For our shortest path BFS.
+type WNPathQueueNode struct {
+	wn     *WordNode
+	parent *WNPathQueueNode
+}
+
+type WNPathQueue struct {
+	nodes []*WNPathQueueNode // nodes in the queue
+}
+
+func (q *WNPathQueue) push(n *WNPathQueueNode) {
+	q.nodes = append(q.nodes, n)
+}
+
+func (q *WNPathQueue) pop() *WNPathQueueNode {
+	if len(q.nodes) > 0 {
+		var retval = q.nodes[0]
+		q.nodes = q.nodes[1:]
+		return retval
+	} else {
+		return nil
+	}
+}
+
+// A priority queue of word nodes with pathing information.  For our A* search.
+// Implements heap.Interface; use push/pop rather than calling those methods directly.
+type WNPriorityQueueNode struct {
+	wn       *WordNode
+	parent   *WNPriorityQueueNode
+	cost     int // steps taken from the start
+	priority int // cost plus estimated steps remaining
+}
+
+type WNPriorityQueue struct {
+	nodes []*WNPriorityQueueNode // heap-ordered nodes in the queue
+}
+
+func (q *WNPriorityQueue) Len() int {
+	return len(q.nodes)
+}
+
+func (q *WNPriorityQueue) Less(i, j int) bool {
+	if q.nodes[i].priority == q.nodes[j].priority {
+		// Prefer nodes further along, they're likely closer to the goal
+		return q.nodes[i].cost > q.nodes[j].cost
+	}
+
+	return q.nodes[i].priority < q.nodes[j].priority
+}
+
+func (q *WNPriorityQueue) Swap(i, j int) {
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+func (q *WNPriorityQueue) Push(x interface{}) {
+	q.nodes = append(q.nodes, x.(*WNPriorityQueueNode))
+}
+
+func (q *WNPriorityQueue) Pop() interface{} {
+	var last = len(q.nodes) - 1
+	var retval = q.nodes[last]
+	q.nodes = q.nodes[:last]
+	return retval
+}
+
+func (q *WNPriorityQueue) push(n *WNPriorityQueueNode) {
+	heap.Push(q, n)
+}
+
+func (q *WNPriorityQueue) pop() *WNPriorityQueueNode {
+	if q.Len() > 0 {
+		return heap.Pop(q).(*WNPriorityQueueNode)
+	} else {
+		return nil
+	}
+}
+
+// A priority queue of word nodes ordered by the total cost of getting to them.  For our Dijkstra search.
+// Implements heap.Interface; use push/pop rather than calling those methods directly.
+type WNCostQueueNode struct {
+	wn     *WordNode
+	parent *WNCostQueueNode
+	cost   float64 // summed edge costs from the start
+}
+
+type WNCostQueue struct {
+	nodes []*WNCostQueueNode // heap-ordered nodes in the queue
+}
+
+func (q *WNCostQueue) Len() int {
+	return len(q.nodes)
+}
+
+func (q *WNCostQueue) Less(i, j int) bool {
+	return q.nodes[i].cost < q.nodes[j].cost
+}
+
+func (q *WNCostQueue) Swap(i, j int) {
+	q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i]
+}
+
+func (q *WNCostQueue) Push(x interface{}) {
+	q.nodes = append(q.nodes, x.(*WNCostQueueNode))
+}
+
+func (q *WNCostQueue) Pop() interface{} {
+	var last = len(q.nodes) - 1
+	var retval = q.nodes[last]
+	q.nodes = q.nodes[:last]
+	return retval
+}
+
+func (q *WNCostQueue) push(n *WNCostQueueNode) {
+	heap.Push(q, n)
+}
+
+func (q *WNCostQueue) pop() *WNCostQueueNode {
+	if q.Len() > 0 {
+		return heap.Pop(q).(*WNCostQueueNode)
+	} else {
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package wordladder
+
+import (
+	"sort"
+)
+
+/**
+ * Optional kinds of edges, on top of the usual single-letter substitution.
+ */
+type EdgeRules struct {
+	Anagrams bool // Words spelled with the same letters are neighbors ("team" -> "mate")
+}
+
+// The letters of a word in sorted order.  Two words are anagrams exactly when their signatures match.
+func anagramSignature(word string) string {
+	var letters = []rune(word)
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return string(letters)
+}
+
+// Do the rules allow a step from s1 to s2, on top of the usual neighbors?
+func (r EdgeRules) allowsStep(s1 string, s2 string) bool {
+	return r.Anagrams && s1 != s2 && anagramSignature(s1) == anagramSignature(s2)
+}
+
+// Rules allowing every kind of edge that either r or other allows
+func (r EdgeRules) merge(other EdgeRules) EdgeRules {
+	return EdgeRules{Anagrams: r.Anagrams || other.Anagrams}
+}
+
+// Bucket every word under its anagram signature
+func (g *WordGraphOfSameLength) buildAnagramIndex() {
+	g.anagramIndex = make(map[string][]*string)
+
+	g.eachNode(func(v *WordNode) {
+		var signature = anagramSignature(v.Word)
+		g.anagramIndex[signature] = append(g.anagramIndex[signature], &v.Word)
+	})
+}
+
+// Figure out the anagrams of a node, other than the word itself
+func (g *WordGraphOfSameLength) figureOutAnagrams(node *WordNode) []*string {
+	if g.anagramIndex == nil {
+		g.buildAnagramIndex()
+	}
+
+	var retval = []*string{}
+
+	for _, word := range g.anagramIndex[anagramSignature(node.Word)] {
+		if *word != node.Word {
+			retval = append(retval, word)
+		}
+	}
+
+	return retval
+}
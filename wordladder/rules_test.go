@@ -0,0 +1,60 @@
+package wordladder
+
+import (
+	"testing"
+)
+
+func TestAnagramEdges(t *testing.T) {
+	// team and mate have no letter in the same place, so only an anagram hop joins them
+	var words = []string{"team", "mate", "male", "meat"}
+
+	var tests = []struct {
+		name  string
+		rules EdgeRules
+		s1    string
+		s2    string
+		path  []string
+	}{
+		{"anagram hop", EdgeRules{Anagrams: true}, "team", "mate", []string{"team", "mate"}},
+		{"anagram then substitution", EdgeRules{Anagrams: true}, "team", "male", []string{"team", "mate", "male"}},
+		{"no anagrams", EdgeRules{}, "team", "male", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphWithRules(test.rules)
+			for _, word := range words {
+				g.AddWord(word)
+			}
+			g.ExploreForests()
+
+			if got := g.ShortestPath(test.s1, test.s2); !samePath(got, test.path) {
+				t.Errorf("got %v, expected %v", got, test.path)
+			}
+
+			if got := g.AreTwoWordsConnected(test.s1, test.s2); got != (test.path != nil) {
+				t.Errorf("AreTwoWordsConnected: got %v", got)
+			}
+		})
+	}
+}
+
+func TestAllowsStep(t *testing.T) {
+	var tests = []struct {
+		rules  EdgeRules
+		s1, s2 string
+		want   bool
+	}{
+		{EdgeRules{Anagrams: true}, "team", "mate", true},
+		{EdgeRules{Anagrams: true}, "team", "team", false},
+		{EdgeRules{Anagrams: true}, "team", "tame", true},
+		{EdgeRules{Anagrams: true}, "team", "teem", false},
+		{EdgeRules{}, "team", "mate", false},
+	}
+
+	for _, test := range tests {
+		if got := test.rules.allowsStep(test.s1, test.s2); got != test.want {
+			t.Errorf("%+v allowsStep(%v, %v): got %v, expected %v", test.rules, test.s1, test.s2, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,535 @@
+package wordladder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The serialized graph is a JSON object with a small header first and the subgraphs last, one per
+// word length:
+//
+//	{"Version": 2, "DictionaryHash": "...", "Rules": {...}, "Graphs": {"3": {...}, "4": {...}, ...}}
+//
+// Each word is {"word": "cat", "forest": 1, "neighbors": ["bat", "cot"]} (see WordNode).
+//
+// It's written and read one subgraph at a time, so only one subgraph's worth of JSON is ever held in
+// memory.  A single subgraph can also be saved on its own (see WordGraphOfSameLength.Save), as
+//
+//	{"Version": 2, "Subgraph": {...}}
+
+// The version of the serialized graph.  Bump it whenever a change (to the neighbor rules, say) means
+// graphs saved before it would give different answers; older files are then refused instead of trusted.
+// Version 2 renamed the WordNode fields to word, forest and neighbors.
+const FormatVersion = 2
+
+// A hash of every word in the graph, for telling whether a saved graph came from the same dictionary.
+// Doesn't depend on the order the words were loaded in.
+func (g *WordGraph) DictionaryHash() string {
+	var h = sha256.New()
+
+	g.EachWord(func(word string) {
+		io.WriteString(h, word)
+		io.WriteString(h, "\n")
+	})
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// A key that's the same for two graphs exactly when they hold the same words, with the same rules,
+// serialized by the same FormatVersion.  Handy for naming cached graph files.
+func (g *WordGraph) CacheKey() string {
+	var retval = fmt.Sprintf("v%d", FormatVersion)
+
+	if g.Rules.Anagrams {
+		retval += "-anagrams"
+	}
+
+	return retval + "-" + g.DictionaryHash()
+}
+
+// Write the graph to w as JSON, one subgraph at a time (see above).
+func (g *WordGraph) WriteJSON(w io.Writer) error {
+	g.exploreIfDirty()
+
+	var bw = bufio.NewWriter(w)
+
+	rules, err := json.Marshal(g.Rules)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(bw, "{\"Version\":%d,\"DictionaryHash\":%q,\"Rules\":%s,\"Graphs\":{", FormatVersion, g.DictionaryHash(), rules)
+
+	var lengths = make([]int, 0, len(g.Graphs))
+	for l := range g.Graphs {
+		lengths = append(lengths, l)
+	}
+	sort.Ints(lengths)
+
+	for i, l := range lengths {
+		if i > 0 {
+			bw.WriteString(",")
+		}
+
+		subgraph, err := g.Graphs[l].marshalJSON()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "\"%d\":%s", l, subgraph)
+	}
+
+	bw.WriteString("}}\n")
+
+	return bw.Flush()
+}
+
+// Read a graph written by WriteJSON from r.
+func ReadJSON(r io.Reader) (*WordGraph, error) {
+	var retval, _, err = decodeGraph(r)
+	return retval, err
+}
+
+// Write the graph to path as gzipped JSON.
+func (g *WordGraph) SaveGzip(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	var zw = gzip.NewWriter(f)
+
+	if err := g.WriteJSON(zw); err != nil {
+		zw.Close()
+		f.Close()
+		return err
+	}
+
+	// Closing the gzip writer flushes the last of the data, so its error matters
+	if err := zw.Close(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Read a graph written by SaveGzip.
+func LoadGzip(path string) (*WordGraph, error) {
+	var retval, _, err = loadGzip(path)
+	return retval, err
+}
+
+// Read a graph written as JSON, unzipping it first if path ends in .gz (see SaveGzip)
+func LoadForest(path string) (*WordGraph, error) {
+	var retval, _, err = loadForest(path)
+	return retval, err
+}
+
+// Like LoadForest, but fails with ErrStaleGraph unless the saved graph was built from a dictionary
+// with the given hash (see DictionaryHash).
+func LoadForestFor(path string, dictionaryHash string) (*WordGraph, error) {
+	retval, savedHash, err := loadForest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if savedHash != dictionaryHash {
+		return nil, ErrStaleGraph
+	}
+
+	return retval, nil
+}
+
+// WordNode as it's written to JSON, with plain strings for neighbors
+type wordNodeJSON struct {
+	Word      string   `json:"word"`
+	ForestTag int      `json:"forest"`
+	Neighbors []string `json:"neighbors"`
+}
+
+// Write the node with its neighbors as a list of strings, which is never null (an unexplored word
+// has no neighbors yet, written as []).
+func (n WordNode) MarshalJSON() ([]byte, error) {
+	var neighbors = make([]string, len(n.Neighbors))
+	for i, neighborWord := range n.Neighbors {
+		neighbors[i] = *neighborWord
+	}
+
+	return json.Marshal(wordNodeJSON{Word: n.Word, ForestTag: n.ForestTag, Neighbors: neighbors})
+}
+
+// Read a node written by MarshalJSON.  The neighbors point at their own copies of the strings until
+// reindex points them at the neighbors' nodes.
+func (n *WordNode) UnmarshalJSON(data []byte) error {
+	var decoded wordNodeJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	n.Word, n.ForestTag = decoded.Word, decoded.ForestTag
+	n.Neighbors = make([]*string, len(decoded.Neighbors))
+
+	for i := range decoded.Neighbors {
+		n.Neighbors[i] = &decoded.Neighbors[i]
+	}
+
+	return nil
+}
+
+// Write just this subgraph to path as JSON (see above), gzipped if path ends in .gz, so one word length
+// can be loaded without all the others.
+func (g *WordGraphOfSameLength) Save(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = f
+	var zw *gzip.Writer
+
+	if strings.HasSuffix(path, ".gz") {
+		zw = gzip.NewWriter(f)
+		w = zw
+	}
+
+	if err := g.writeJSON(w); err != nil {
+		if zw != nil {
+			zw.Close()
+		}
+		f.Close()
+		return err
+	}
+
+	// Closing the gzip writer flushes the last of the data, so its error matters
+	if zw != nil {
+		if err := zw.Close(); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	return f.Close()
+}
+
+// Write the subgraph to w as JSON, headed by the FormatVersion
+func (g *WordGraphOfSameLength) writeJSON(w io.Writer) error {
+	subgraph, err := g.marshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "{\"Version\":%d,\"Subgraph\":%s}\n", FormatVersion, subgraph)
+	return err
+}
+
+// The subgraph as JSON.  The words are always written as a map, so with SortedStorage one is made
+// for the occasion (see wordMap).
+func (g *WordGraphOfSameLength) marshalJSON() ([]byte, error) {
+	if g.sorted == nil {
+		return json.Marshal(g)
+	}
+
+	var view = *g
+	view.WordGraph = g.wordMap()
+	return json.Marshal(&view)
+}
+
+// Read a subgraph written by WordGraphOfSameLength.Save, unzipping it first if path ends in .gz.
+// Add it to a graph with AddSubgraph, to load only the word lengths you need.
+func LoadSubgraph(path string) (*WordGraphOfSameLength, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+
+		r = zr
+	}
+
+	return decodeSubgraph(r)
+}
+
+// LoadGzip, also returning the dictionary hash the graph was saved with
+func loadGzip(path string) (*WordGraph, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, "", err
+	}
+	defer zr.Close()
+
+	return decodeGraph(zr)
+}
+
+// LoadForest, also returning the dictionary hash the graph was saved with
+func loadForest(path string) (*WordGraph, string, error) {
+	if strings.HasSuffix(path, ".gz") {
+		return loadGzip(path)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	return decodeGraph(f)
+}
+
+// Decode a JSON graph, one subgraph at a time, returning it and the dictionary hash it was saved with.
+// Any error means the graph is incomplete, so it's thrown away.  So is a graph from another FormatVersion,
+// which is checked before any subgraphs are read (WriteJSON puts the version first).
+func decodeGraph(r io.Reader) (*WordGraph, string, error) {
+	var retval = NewWordGraph()
+	var version = 0
+	var dictionaryHash = ""
+	var dec = json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, "", err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, "", err
+		}
+
+		// Field names match the way encoding/json matches them, ignoring case
+		var name, _ = key.(string)
+
+		switch {
+		case strings.EqualFold(name, "Version"):
+			err = dec.Decode(&version)
+			if err == nil && version != FormatVersion {
+				err = versionError(version)
+			}
+		case strings.EqualFold(name, "DictionaryHash"):
+			err = dec.Decode(&dictionaryHash)
+		case strings.EqualFold(name, "Graphs"):
+			if version != FormatVersion {
+				// No version before the subgraphs, so it's an old file (or not one of ours)
+				err = versionError(version)
+			} else {
+				err = decodeSubgraphs(dec, retval)
+			}
+		case strings.EqualFold(name, "Rules"):
+			err = dec.Decode(&retval.Rules)
+		default:
+			// Something we don't know about, skip it
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, "", err
+	}
+
+	if version != FormatVersion {
+		return nil, "", versionError(version)
+	}
+
+	retval.reindex()
+
+	return retval, dictionaryHash, nil
+}
+
+// Decode a subgraph saved on its own, checking the FormatVersion first like decodeGraph does
+func decodeSubgraph(r io.Reader) (*WordGraphOfSameLength, error) {
+	var retval *WordGraphOfSameLength
+	var version = 0
+	var dec = json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var name, _ = key.(string)
+
+		switch {
+		case strings.EqualFold(name, "Version"):
+			err = dec.Decode(&version)
+			if err == nil && version != FormatVersion {
+				err = versionError(version)
+			}
+		case strings.EqualFold(name, "Subgraph"):
+			if version != FormatVersion {
+				err = versionError(version)
+			} else {
+				err = dec.Decode(&retval)
+			}
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+
+	if version != FormatVersion {
+		return nil, versionError(version)
+	}
+
+	if retval == nil {
+		return nil, fmt.Errorf("no subgraph in file")
+	}
+
+	retval.reindex()
+
+	return retval, nil
+}
+
+// The error for a graph saved with the wrong FormatVersion (0 if it didn't say)
+func versionError(version int) error {
+	return fmt.Errorf("%w: %d (expected %d)", ErrUnsupportedVersion, version, FormatVersion)
+}
+
+// Decode the "Graphs" object, adding each subgraph to g as soon as it's read
+func decodeSubgraphs(dec *json.Decoder, g *WordGraph) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok == nil {
+		// null, there aren't any
+		return nil
+	}
+
+	if tok != json.Delim('{') {
+		return fmt.Errorf("expected an object of subgraphs, got %v", tok)
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		var name, _ = key.(string)
+		l, err := strconv.Atoi(name)
+		if err != nil {
+			return fmt.Errorf("bad word length %q: %w", name, err)
+		}
+
+		var subgraph *WordGraphOfSameLength
+		if err := dec.Decode(&subgraph); err != nil {
+			return err
+		}
+
+		g.Graphs[l] = subgraph
+	}
+
+	return expectDelim(dec, '}')
+}
+
+// Read the next token, which has to be the given delimiter
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if tok != delim {
+		return fmt.Errorf("expected %v, got %v", delim, tok)
+	}
+
+	return nil
+}
+
+// Rebuild the bookkeeping that doesn't survive serialization (or came from an older file without it)
+func (g *WordGraph) reindex() {
+	for l, subgraph := range g.Graphs {
+		if subgraph == nil {
+			delete(g.Graphs, l)
+			continue
+		}
+
+		subgraph.reindex()
+
+		// Only the subgraphs remember these, so re-exploring doesn't undo them
+		if subgraph.Directed {
+			g.Directed = true
+		}
+
+		if subgraph.LazyNeighbors {
+			g.LazyNeighbors = true
+		}
+
+		g.Rules = g.Rules.merge(subgraph.Rules)
+	}
+}
+
+// Rebuild a decoded subgraph's bookkeeping
+func (g *WordGraphOfSameLength) reindex() {
+	if g.WordGraph == nil && g.sorted == nil {
+		g.WordGraph = make(map[string]*WordNode)
+	}
+
+	g.eachNode(func(v *WordNode) {
+		// New forests have to be numbered after every tag already handed out
+		if v.ForestTag >= g.CurForest {
+			g.CurForest = v.ForestTag + 1
+		}
+
+		// Decoding gives every neighbor entry its own copy of the string.  Point them back at
+		// the neighbor's own Word so each word is only stored once.
+		for i, neighborWord := range v.Neighbors {
+			if neighbor := g.node(*neighborWord); neighbor != nil {
+				v.Neighbors[i] = &neighbor.Word
+			}
+
+			// Links aren't marked in the file, so play it safe with anything more than one change away
+			if !areNeighbors(v.Word, *neighborWord) {
+				g.hasCustomLinks = true
+			}
+		}
+	})
+
+	if g.CurForest < 1 {
+		g.CurForest = 1
+	}
+
+	// The file doesn't say which NeighborFunc (if any) it was explored with, so a graph explored with
+	// one is worked out again as soon as it's explored with one set
+	g.exploredWith = edgeSettings{rules: g.Rules, directed: g.Directed}
+}
@@ -0,0 +1,578 @@
+package wordladder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSubgraphSaveLoad(t *testing.T) {
+	var tests = []struct {
+		name string
+		file string
+	}{
+		{"json", "five.json"},
+		{"gzip", "five.json.gz"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords([]string{"plant", "plans", "plane", "place", "crane", "cat"})
+			var path = filepath.Join(t.TempDir(), test.file)
+
+			if err := g.Graphs[5].Save(path); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := LoadSubgraph(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if loaded.WordLength != 5 || !reflect.DeepEqual(loaded.Words(), g.Graphs[5].Words()) {
+				t.Errorf("got %v-letter words %v, expected %v", loaded.WordLength, loaded.Words(), g.Graphs[5].Words())
+			}
+
+			for _, word := range g.Graphs[5].Words() {
+				if got, want := loaded.WordGraph[word].ForestTag, g.Graphs[5].WordGraph[word].ForestTag; got != want {
+					t.Errorf("%v: got forest %v, expected %v", word, got, want)
+				}
+			}
+
+			if got := loaded.ShortestPath("plant", "place"); !samePath(got, []string{"plant", "plane", "place"}) {
+				t.Errorf("got %v after loading", got)
+			}
+		})
+	}
+}
+
+func TestAddSubgraphKeepsRules(t *testing.T) {
+	var g = NewWordGraphWithRules(EdgeRules{Anagrams: true})
+	for _, word := range []string{"team", "mate", "meat", "moat"} {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	var path = filepath.Join(t.TempDir(), "four.json")
+	if err := g.Graphs[4].Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSubgraph(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var other = NewWordGraph()
+	other.AddSubgraph(loaded)
+
+	// Exploring again mustn't drop the anagram edges
+	other.AddWord("boat")
+
+	if got := other.ShortestPath("team", "mate"); !samePath(got, []string{"team", "mate"}) {
+		t.Errorf("got %v, expected [team mate]", got)
+	}
+
+	if !other.Rules.Anagrams {
+		t.Errorf("the graph should have taken the subgraph's rules")
+	}
+}
+
+// Map of word to forest tag, for every word in the graph
+func forestTags(g *WordGraph) map[string]int {
+	var retval = make(map[string]int)
+
+	g.EachWord(func(word string) {
+		_, tag, _ := g.ForestTag(word)
+		retval[word] = tag
+	})
+
+	return retval
+}
+
+func TestCurForestSurvivesReload(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureColdWarm)
+
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := ReadJSON(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := loaded.Graphs[4].CurForest, g.Graphs[4].CurForest; got != want {
+		t.Errorf("got CurForest %v after reloading, expected %v", got, want)
+	}
+
+	// A word on its own makes a new forest, which mustn't reuse a tag
+	loaded.AddWord("zzzz")
+	loaded.ExploreForests()
+
+	var tags = forestTags(loaded)
+	for _, word := range []string{"cold", "fish"} {
+		if tags["zzzz"] == tags[word] {
+			t.Errorf("zzzz got the same forest tag as %v (%v)", word, tags[word])
+		}
+	}
+
+	if got := loaded.GetTotalForests(); got != 3 {
+		t.Errorf("got %v forests, expected 3", got)
+	}
+}
+
+func TestSaveGzip(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+	var path = filepath.Join(t.TempDir(), "wordForest.json.gz")
+
+	if err := g.SaveGzip(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var loaders = []struct {
+		name string
+		load func(path string) (*WordGraph, error)
+	}{
+		{"LoadGzip", LoadGzip},
+		{"LoadForest", LoadForest},
+	}
+
+	for _, loader := range loaders {
+		t.Run(loader.name, func(t *testing.T) {
+			loaded, err := loader.load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := forestTags(loaded), forestTags(g); !reflect.DeepEqual(got, want) {
+				t.Errorf("got forests %v, expected %v", got, want)
+			}
+		})
+	}
+}
+
+func TestLoadForest(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+	var dir = t.TempDir()
+
+	var good = filepath.Join(dir, "good.json.gz")
+	if err := g.SaveGzip(good); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name     string
+		contents string // Written over the file first, unless empty
+		ok       bool
+	}{
+		{"saved graph", "", true},
+		{"truncated", `{"graphs":{"3":{"wordLength":3,"wordGraph":{"cat":`, false},
+		{"not json", "this is not a forest", false},
+		{"wrong types", `{"graphs":"cat"}`, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var path = good
+			if test.contents != "" {
+				path = filepath.Join(dir, "bad.json")
+				if err := os.WriteFile(path, []byte(test.contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			loaded, err := LoadForest(path)
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, expected ok=%v", err, test.ok)
+			}
+
+			if !test.ok {
+				if loaded != nil {
+					t.Errorf("got a graph along with error %v", err)
+				}
+				return
+			}
+
+			if got := loaded.ShortestPath("cat", "dog"); len(got) != 4 {
+				t.Errorf("got %v from the loaded graph", got)
+			}
+		})
+	}
+
+	if _, err := LoadForest(filepath.Join(dir, "missing.json")); err == nil {
+		t.Errorf("loading a missing file should fail")
+	}
+}
+
+func TestCountsSurviveReload(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+	}{
+		{"cat dog", fixtureCatDog},
+		{"cold warm", fixtureColdWarm},
+		{"mixed", fixtureMixed},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words)
+
+			var buf bytes.Buffer
+			if err := g.WriteJSON(&buf); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := ReadJSON(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := loaded.GetTotalWords(), g.GetTotalWords(); got != want {
+				t.Errorf("got %v words after reloading, expected %v", got, want)
+			}
+
+			if got, want := loaded.GetTotalForests(), g.GetTotalForests(); got != want {
+				t.Errorf("got %v forests after reloading, expected %v", got, want)
+			}
+
+			for l, subgraph := range g.Graphs {
+				if got, want := loaded.Graphs[l].GetTotalForests(), subgraph.GetTotalForests(); got != want {
+					t.Errorf("%v letters: got %v forests after reloading, expected %v", l, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReloadSharesNeighborStrings(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+	}{
+		{"cat dog", fixtureCatDog},
+		{"cold warm", fixtureColdWarm},
+		{"random", randomWords(500, 3, 1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words)
+
+			var buf bytes.Buffer
+			if err := g.WriteJSON(&buf); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := ReadJSON(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, subgraph := range loaded.Graphs {
+				subgraph.eachNode(func(v *WordNode) {
+					if cap(v.Neighbors) != len(v.Neighbors) {
+						t.Errorf("%v has room for %v neighbors, but only %v", v.Word, cap(v.Neighbors), len(v.Neighbors))
+					}
+
+					for _, neighborWord := range v.Neighbors {
+						if neighborWord != &subgraph.node(*neighborWord).Word {
+							t.Errorf("%v's neighbor %v is a copy, not the neighbor's own Word", v.Word, *neighborWord)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+// Reloading a graph, with each neighbor entry keeping its own copy of the string as decoding leaves it,
+// and with them shared with the neighbors' nodes (run with -bench ReadJSON)
+func BenchmarkReadJSON(b *testing.B) {
+	var g = NewWordGraph()
+	g.UseWildcardIndex = true
+	for _, word := range randomWords(8000, 4, 1) {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	var buf bytes.Buffer
+	if err := g.WriteJSON(&buf); err != nil {
+		b.Fatal(err)
+	}
+
+	var unshare = func(g *WordGraph) {
+		for _, subgraph := range g.Graphs {
+			subgraph.eachNode(func(v *WordNode) {
+				for i, neighborWord := range v.Neighbors {
+					var word = strings.Clone(*neighborWord)
+					v.Neighbors[i] = &word
+				}
+			})
+		}
+	}
+
+	for _, shared := range []bool{false, true} {
+		var name = "copies"
+		if shared {
+			name = "shared"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			var perWord float64
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				var before = heapInUse()
+				b.StartTimer()
+
+				loaded, err := ReadJSON(bytes.NewReader(buf.Bytes()))
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				if !shared {
+					unshare(loaded)
+				}
+
+				b.StopTimer()
+				perWord = float64(heapInUse()-before) / float64(loaded.GetTotalWords())
+				runtime.KeepAlive(loaded)
+				b.StartTimer()
+			}
+
+			b.ReportMetric(perWord, "heap-bytes/word")
+		})
+	}
+}
+
+func TestStreamedLoadMatchesWholeFile(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+	}{
+		{"mixed", fixtureMixed},
+		{"cold warm", fixtureColdWarm},
+		{"many lengths", manyLengths()[:3000]},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraph()
+			g.UseWildcardIndex = true
+			for _, word := range test.words {
+				g.AddWord(word)
+			}
+			g.ExploreForests()
+
+			var buf bytes.Buffer
+			if err := g.WriteJSON(&buf); err != nil {
+				t.Fatal(err)
+			}
+
+			// The whole file decoded in one go
+			var whole struct {
+				Graphs map[int]*WordGraphOfSameLength
+			}
+			if err := json.Unmarshal(buf.Bytes(), &whole); err != nil {
+				t.Fatal(err)
+			}
+
+			streamed, err := ReadJSON(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(streamed.Graphs) != len(whole.Graphs) {
+				t.Fatalf("got %v word lengths streamed, %v whole", len(streamed.Graphs), len(whole.Graphs))
+			}
+
+			for l, subgraph := range whole.Graphs {
+				var other = streamed.Graphs[l]
+
+				if other == nil || other.CurForest != subgraph.CurForest || !reflect.DeepEqual(other.Words(), subgraph.Words()) {
+					t.Errorf("%v letters: streamed subgraph doesn't match", l)
+					continue
+				}
+
+				for _, word := range subgraph.Words() {
+					var got, want = other.node(word), subgraph.node(word)
+
+					if got.ForestTag != want.ForestTag || !reflect.DeepEqual(wordsOf(got.Neighbors), wordsOf(want.Neighbors)) {
+						t.Errorf("%v: streamed %v in forest %v, whole %v in forest %v", word, wordsOf(got.Neighbors), got.ForestTag, wordsOf(want.Neighbors), want.ForestTag)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStreamedLoadFieldOrder(t *testing.T) {
+	var subgraph = `{"CurForest":2,"WordLength":3,"WordGraph":{"cat":{"word":"cat","forest":1,"neighbors":["cot"]},"cot":{"word":"cot","forest":1,"neighbors":["cat"]}}}`
+
+	var tests = []struct {
+		name string
+		json string
+		ok   bool
+	}{
+		{"written order", `{"Version":2,"DictionaryHash":"x","Graphs":{"3":` + subgraph + `}}`, true},
+		{"hash last", `{"Version":2,"Graphs":{"3":` + subgraph + `},"DictionaryHash":"x"}`, true},
+		{"unknown fields", `{"Extra":[1,{"a":2}],"Version":2,"Graphs":{"3":` + subgraph + `},"More":null}`, true},
+		{"no graphs", `{"Version":2,"Graphs":null}`, true},
+		{"version after graphs", `{"Graphs":{"3":` + subgraph + `},"Version":2}`, false},
+		{"bad length", `{"Version":2,"Graphs":{"three":` + subgraph + `}}`, false},
+		{"unclosed", `{"Version":2,"Graphs":{"3":` + subgraph, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g, err := ReadJSON(strings.NewReader(test.json))
+			if (err == nil) != test.ok {
+				t.Fatalf("got error %v, expected ok=%v", err, test.ok)
+			}
+
+			if test.ok && g.Graphs[3] != nil && !samePath(g.ShortestPath("cat", "cot"), []string{"cat", "cot"}) {
+				t.Errorf("got %v", g.ShortestPath("cat", "cot"))
+			}
+		})
+	}
+}
+
+func TestVersionMismatch(t *testing.T) {
+	var tests = []struct {
+		name string
+		json string
+		err  error
+	}{
+		{"current", fmt.Sprintf(`{"Version":%d,"Graphs":{}}`, FormatVersion), nil},
+		{"older", `{"Version":1,"Graphs":{}}`, ErrUnsupportedVersion},
+		{"newer", fmt.Sprintf(`{"Version":%d,"Graphs":{}}`, FormatVersion+1), ErrUnsupportedVersion},
+		{"missing", `{"Graphs":{}}`, ErrUnsupportedVersion},
+		{"subgraph current", fmt.Sprintf(`{"Version":%d,"Subgraph":{"WordLength":3,"WordGraph":{}}}`, FormatVersion), nil},
+		{"subgraph older", `{"Version":1,"Subgraph":{"WordLength":3,"WordGraph":{}}}`, ErrUnsupportedVersion},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var err error
+			if strings.Contains(test.json, "Subgraph") {
+				var path = filepath.Join(t.TempDir(), "subgraph.json")
+				if err := os.WriteFile(path, []byte(test.json), 0644); err != nil {
+					t.Fatal(err)
+				}
+				_, err = LoadSubgraph(path)
+			} else {
+				_, err = ReadJSON(strings.NewReader(test.json))
+			}
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("got %v, expected %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestLoadForestForDictionary(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+	var path = filepath.Join(t.TempDir(), "forest.json.gz")
+	if err := g.SaveGzip(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		name string
+		hash string
+		err  error
+	}{
+		{"same words", NewWordGraphFromWords(fixtureCatDog).DictionaryHash(), nil},
+		{"same words, another order", NewWordGraphFromWords([]string{"dog", "cat", "hot", "bat", "cog", "cot", "dot", "hat"}).DictionaryHash(), nil},
+		{"another word", NewWordGraphFromWords(append([]string{"cut"}, fixtureCatDog...)).DictionaryHash(), ErrStaleGraph},
+		{"other words", NewWordGraphFromWords(fixtureColdWarm).DictionaryHash(), ErrStaleGraph},
+	}
+
+	for _, test := range tests {
+		if _, err := LoadForestFor(path, test.hash); !errors.Is(err, test.err) {
+			t.Errorf("%v: got %v, expected %v", test.name, err, test.err)
+		}
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	var anagrams = NewWordGraphWithRules(EdgeRules{Anagrams: true})
+	for _, word := range fixtureCatDog {
+		anagrams.AddWord(word)
+	}
+
+	var tests = []struct {
+		name string
+		a, b *WordGraph
+		same bool
+	}{
+		{"same words", NewWordGraphFromWords(fixtureCatDog), NewWordGraphFromWords(fixtureCatDog), true},
+		{"different words", NewWordGraphFromWords(fixtureCatDog), NewWordGraphFromWords(fixtureMixed), false},
+		{"different rules", NewWordGraphFromWords(fixtureCatDog), anagrams, false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.CacheKey() == test.b.CacheKey(); got != test.same {
+			t.Errorf("%v: got keys %v and %v", test.name, test.a.CacheKey(), test.b.CacheKey())
+		}
+	}
+
+	if key := NewWordGraph().CacheKey(); !strings.HasPrefix(key, fmt.Sprintf("v%d-", FormatVersion)) {
+		t.Errorf("got %v, expected it to start with the format version", key)
+	}
+}
+
+func TestWordNodeJSON(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog)
+	var cat = g.Graphs[3].WordGraph["cat"]
+	var unexplored = newSubgraph([]string{"cut"}, false).WordGraph["cut"]
+
+	var tests = []struct {
+		name string
+		node *WordNode
+		want string
+	}{
+		{"explored", cat, fmt.Sprintf(`{"word":"cat","forest":%v,"neighbors":["bat","cot","hat"]}`, cat.ForestTag)},
+		{"unexplored", unexplored, `{"word":"cut","forest":0,"neighbors":[]}`},
+		{"unicode", &WordNode{Word: "żuk", ForestTag: 2}, `{"word":"żuk","forest":2,"neighbors":[]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(test.node)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(got) != test.want {
+				t.Errorf("got %s, expected %s", got, test.want)
+			}
+
+			// A value marshals the same as a pointer
+			if byValue, _ := json.Marshal(*test.node); string(byValue) != test.want {
+				t.Errorf("by value: got %s, expected %s", byValue, test.want)
+			}
+
+			var back WordNode
+			if err := json.Unmarshal(got, &back); err != nil {
+				t.Fatal(err)
+			}
+
+			if back.Word != test.node.Word || back.ForestTag != test.node.ForestTag || !samePath(wordsOf(back.Neighbors), wordsOf(test.node.Neighbors)) {
+				t.Errorf("got %+v back, expected %+v", back, *test.node)
+			}
+		})
+	}
+}
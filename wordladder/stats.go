@@ -0,0 +1,85 @@
+package wordladder
+
+import (
+	"time"
+)
+
+/**
+ * Summary of a loaded graph's size.
+ */
+type GraphStats struct {
+	TotalWords      int         // words across all lengths
+	TotalForests    int         // forests across all lengths
+	DistinctLengths int         // number of word lengths with a subgraph
+	TotalEdges      int         // neighbor pairs, each counted once (each direction separately if Directed)
+	WordsByLength   map[int]int // word length to number of words
+}
+
+// Gather size statistics for the whole graph
+func (g *WordGraph) Stats() GraphStats {
+	g.exploreIfDirty()
+
+	var retval = GraphStats{
+		TotalWords:      g.GetTotalWords(),
+		TotalForests:    g.GetTotalForests(),
+		DistinctLengths: g.GetTotalDistinctWordLengths(),
+		WordsByLength:   make(map[int]int),
+	}
+
+	for l, subgraph := range g.Graphs {
+		retval.WordsByLength[l] = subgraph.GetTotalWords()
+		retval.TotalEdges += subgraph.EdgeCount()
+	}
+
+	return retval
+}
+
+// Number of neighbor pairs, each counted once.  In a Directed graph each one-way edge counts, so a pair
+// joined both ways is two.  Neighbors are only known once forests have been explored.
+func (g *WordGraphOfSameLength) EdgeCount() int {
+	var neighborEntries = 0
+
+	g.eachNode(func(v *WordNode) {
+		neighborEntries += len(g.neighborsOf(v))
+	})
+
+	if g.Directed {
+		// Each edge is only listed from the word it starts at
+		return neighborEntries
+	}
+
+	// Every edge is listed from both ends
+	return neighborEntries / 2
+}
+
+// Average number of neighbors per word, a rough measure of how easy ladders of this length are.
+// In a Directed graph that's the words each word can change into.  0 if there are no words.
+func (g *WordGraphOfSameLength) AverageDegree() float64 {
+	if g.GetTotalWords() == 0 {
+		return 0
+	}
+
+	if g.Directed {
+		return float64(g.EdgeCount()) / float64(g.GetTotalWords())
+	}
+
+	return 2 * float64(g.EdgeCount()) / float64(g.GetTotalWords())
+}
+
+/**
+ * How long ExploreForestsTimed took, overall and for each word length.
+ */
+type ExploreReport struct {
+	Duration time.Duration        // wall clock time for the whole exploration
+	Lengths  map[int]LengthReport // word length to how exploring those words went
+}
+
+/**
+ * How exploring the words of one length went.  Lengths are explored in parallel, so these
+ * durations can add up to more than the overall one.
+ */
+type LengthReport struct {
+	Duration time.Duration // time spent exploring this length
+	Words    int           // words of this length
+	Forests  int           // forests they make up
+}
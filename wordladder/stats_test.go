@@ -0,0 +1,149 @@
+package wordladder
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEdgeCount(t *testing.T) {
+	var tests = []struct {
+		name     string
+		directed bool
+		words    []string
+		link     []string
+		edges    int
+		degree   float64
+	}{
+		// cat-cot-cog-dog is a chain of three edges
+		{"chain", false, []string{"cat", "cot", "cog", "dog"}, nil, 3, 1.5},
+		{"isolated", false, []string{"cat", "xyz"}, nil, 0, 0},
+		{"empty", false, []string{}, nil, 0, 0},
+		{"directed pair", true, []string{"cat", "cot"}, nil, 2, 1},
+		{"directed link", true, []string{"cat", "zzz"}, []string{"zzz", "cat"}, 1, 0.5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphOfSameLength(3)
+			g.Directed = test.directed
+			for _, word := range test.words {
+				g.AddWord(word)
+			}
+			g.ExploreAllForests()
+
+			if test.link != nil {
+				if err := g.Link(test.link[0], test.link[1]); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if got := g.EdgeCount(); got != test.edges {
+				t.Errorf("EdgeCount: got %v, expected %v", got, test.edges)
+			}
+
+			if got := g.AverageDegree(); got != test.degree {
+				t.Errorf("AverageDegree: got %v, expected %v", got, test.degree)
+			}
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+		want  GraphStats
+	}{
+		// a-i, at-it, cat-cot and cold-cord; dog and fish are on their own
+		{"mixed", fixtureMixed, GraphStats{TotalWords: 10, TotalForests: 6, DistinctLengths: 4, TotalEdges: 4,
+			WordsByLength: map[int]int{1: 2, 2: 2, 3: 3, 4: 3}}},
+		// Degrees bat 2, cat 3, cog 2, cot 4, dog 2, dot 3, hat 3, hot 3 add up to 22, each edge twice
+		{"cat dog", fixtureCatDog, GraphStats{TotalWords: 8, TotalForests: 1, DistinctLengths: 1, TotalEdges: 11,
+			WordsByLength: map[int]int{3: 8}}},
+		{"empty", []string{}, GraphStats{WordsByLength: map[int]int{}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := NewWordGraphFromWords(test.words).Stats(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, expected %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEdgeCountOnFixtures(t *testing.T) {
+	var tests = []struct {
+		name   string
+		words  []string
+		length int
+		edges  int
+		degree float64
+	}{
+		// Degrees bat 2, cat 3, cog 2, cot 4, dog 2, dot 3, hat 3, hot 3
+		{"cat dog", fixtureCatDog, 3, 11, 2.75},
+		// Eight edges round the two squares and out to cold, plus dish-fish
+		{"cold warm", fixtureColdWarm, 4, 9, 2},
+		{"mixed threes", fixtureMixed, 3, 1, 2.0 / 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words).Graphs[test.length]
+
+			// Every edge is in both words' neighbor lists, but only counted once
+			var entries = 0
+			g.eachNode(func(v *WordNode) {
+				entries += len(v.Neighbors)
+			})
+
+			if got := g.EdgeCount(); got != test.edges || entries != 2*got {
+				t.Errorf("EdgeCount: got %v from %v neighbor entries, expected %v", got, entries, test.edges)
+			}
+
+			if got := g.AverageDegree(); got != test.degree {
+				t.Errorf("AverageDegree: got %v, expected %v", got, test.degree)
+			}
+		})
+	}
+}
+
+func TestExploreForestsTimed(t *testing.T) {
+	var g = NewWordGraph()
+	for _, word := range fixtureMixed {
+		g.AddWord(word)
+	}
+
+	var report = g.ExploreForestsTimed()
+
+	var want = map[int]LengthReport{
+		1: {Words: 2, Forests: 1},
+		2: {Words: 2, Forests: 1},
+		3: {Words: 3, Forests: 2},
+		4: {Words: 3, Forests: 2},
+	}
+
+	if len(report.Lengths) != len(g.Graphs) {
+		t.Errorf("got reports for %v lengths, expected %v", len(report.Lengths), len(g.Graphs))
+	}
+
+	for l, expected := range want {
+		var got, ok = report.Lengths[l]
+		if !ok {
+			t.Errorf("no report for %v letters", l)
+			continue
+		}
+
+		if got.Duration < 0 || got.Duration > report.Duration {
+			t.Errorf("%v letters: took %v, overall %v", l, got.Duration, report.Duration)
+		}
+
+		if got.Words != expected.Words || got.Forests != expected.Forests {
+			t.Errorf("%v letters: got %v words in %v forests, expected %v in %v", l, got.Words, got.Forests, expected.Words, expected.Forests)
+		}
+	}
+
+	if report.Duration < 0 || g.IsDirty() {
+		t.Errorf("got duration %v, dirty %v", report.Duration, g.IsDirty())
+	}
+}
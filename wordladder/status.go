@@ -0,0 +1,52 @@
+package wordladder
+
+import (
+	"fmt"
+)
+
+// Whether two words are connected, and if not, why not.  See WordGraph.ConnectionStatus.
+type ConnectionStatus int
+
+const (
+	Connected        ConnectionStatus = iota // Same forest, there's a ladder between them
+	LengthMismatch                           // Different lengths, no substitution ladder can join them
+	WordMissing                              // At least one of the words isn't in the dictionary
+	DifferentForests                         // Both in the dictionary, but in forests that don't touch (or one way only, if Directed)
+)
+
+func (s ConnectionStatus) String() string {
+	switch s {
+	case Connected:
+		return "Connected"
+	case LengthMismatch:
+		return "LengthMismatch"
+	case WordMissing:
+		return "WordMissing"
+	case DifferentForests:
+		return "DifferentForests"
+	default:
+		return fmt.Sprintf("ConnectionStatus(%d)", int(s))
+	}
+}
+
+// Say whether two words are connected, and if not, why: LengthMismatch, WordMissing, or DifferentForests.
+// Like AreTwoWordsConnected, this reads the forest tags, exploring first if the graph is dirty.
+func (g *WordGraph) ConnectionStatus(s1 string, s2 string) ConnectionStatus {
+	g.exploreIfDirty()
+
+	s1, s2 = g.fold(s1), g.fold(s2)
+
+	if wordLength(s1) != wordLength(s2) {
+		return LengthMismatch
+	}
+
+	if g.node(s1) == nil || g.node(s2) == nil {
+		return WordMissing
+	}
+
+	if !g.Graphs[wordLength(s1)].AreTwoWordsConnected(s1, s2) {
+		return DifferentForests
+	}
+
+	return Connected
+}
@@ -0,0 +1,60 @@
+package wordladder
+
+import (
+	"testing"
+)
+
+func TestConnectionStatus(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureMixed)
+
+	var tests = []struct {
+		s1, s2 string
+		want   ConnectionStatus
+		name   string
+	}{
+		{"cat", "cot", Connected, "Connected"},
+		{"cat", "cat", Connected, "Connected"},
+		{"cat", "cold", LengthMismatch, "LengthMismatch"},
+		{"cat", "cut", WordMissing, "WordMissing"},
+		{"cut", "cat", WordMissing, "WordMissing"},
+		{"zzzzz", "yyyyy", WordMissing, "WordMissing"}, // No five-letter words at all
+		{"cat", "dog", DifferentForests, "DifferentForests"},
+		{"cold", "fish", DifferentForests, "DifferentForests"},
+	}
+
+	for _, test := range tests {
+		var got = g.ConnectionStatus(test.s1, test.s2)
+
+		if got != test.want {
+			t.Errorf("ConnectionStatus(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.want)
+		}
+
+		if got.String() != test.name {
+			t.Errorf("ConnectionStatus(%v, %v): got %q, expected %q", test.s1, test.s2, got.String(), test.name)
+		}
+	}
+
+	if got := ConnectionStatus(99).String(); got != "ConnectionStatus(99)" {
+		t.Errorf("got %q for an unknown status", got)
+	}
+
+	// A one-way link only connects the words one way round
+	var directed = NewWordGraph()
+	directed.Directed = true
+	for _, word := range []string{"cat", "dog"} {
+		directed.AddWord(word)
+	}
+	directed.ExploreForests()
+
+	if err := directed.Link("cat", "dog"); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := directed.ConnectionStatus("cat", "dog"); got != Connected {
+		t.Errorf("cat -> dog: got %v, expected Connected", got)
+	}
+
+	if got := directed.ConnectionStatus("dog", "cat"); got != DifferentForests {
+		t.Errorf("dog -> cat: got %v, expected DifferentForests", got)
+	}
+}
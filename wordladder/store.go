@@ -0,0 +1,193 @@
+package wordladder
+
+import (
+	"slices"
+	"strings"
+)
+
+/**
+ * How a subgraph keeps its words.
+ */
+type StorageKind int
+
+const (
+	MapStorage    StorageKind = iota // In the WordGraph map, the quickest to look words up in
+	SortedStorage                    // In a list sorted by word, found by binary search.  Smaller than the map, a little slower
+)
+
+/**
+ * Words kept in a sorted list rather than a map (see SortedStorage).  New words go on a short
+ * unsorted list first, and are merged in once there are enough of them, so adding isn't O(n) a word.
+ */
+type sortedWords struct {
+	nodes   []*WordNode // Sorted by word
+	pending []*WordNode // Added since the last merge, in no particular order
+}
+
+// How many words sortedWords collects before merging them into the sorted list
+const pendingLimit = 256
+
+// Compare a node to a word, for binary searches
+func compareNode(node *WordNode, word string) int {
+	return strings.Compare(node.Word, word)
+}
+
+// The node for a word, nil if it isn't there
+func (s *sortedWords) get(word string) *WordNode {
+	if i, found := slices.BinarySearchFunc(s.nodes, word, compareNode); found {
+		return s.nodes[i]
+	}
+
+	for _, v := range s.pending {
+		if v.Word == word {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// Add a node for a word that isn't there yet
+func (s *sortedWords) add(node *WordNode) {
+	s.pending = append(s.pending, node)
+
+	if len(s.pending) >= pendingLimit {
+		s.merge()
+	}
+}
+
+// Remove a word, if it's there
+func (s *sortedWords) remove(word string) {
+	if i, found := slices.BinarySearchFunc(s.nodes, word, compareNode); found {
+		s.nodes = slices.Delete(s.nodes, i, i+1)
+		return
+	}
+
+	for i, v := range s.pending {
+		if v.Word == word {
+			s.pending = slices.Delete(s.pending, i, i+1)
+			return
+		}
+	}
+}
+
+// Merge the pending words into the sorted list.  The list grows to fit and they're merged in from the
+// back, so nothing is allocated besides the growth.
+func (s *sortedWords) merge() {
+	if len(s.pending) == 0 {
+		return
+	}
+
+	sortNodes(s.pending)
+
+	var i, j = len(s.nodes) - 1, len(s.pending) - 1
+	s.nodes = append(s.nodes, s.pending...)
+
+	for k := len(s.nodes) - 1; j >= 0; k-- {
+		if i >= 0 && s.nodes[i].Word > s.pending[j].Word {
+			s.nodes[k] = s.nodes[i]
+			i--
+		} else {
+			s.nodes[k] = s.pending[j]
+			j--
+		}
+	}
+
+	s.pending = s.pending[:0]
+}
+
+// Merge everything and give back the room the list grew into, once no more words are coming
+func (s *sortedWords) compact() {
+	s.merge()
+	s.pending = nil
+
+	if cap(s.nodes) > len(s.nodes)+len(s.nodes)/8 {
+		s.nodes = slices.Clone(s.nodes)
+	}
+}
+
+// The node for a word, nil if it isn't in the graph
+func (g *WordGraphOfSameLength) node(word string) *WordNode {
+	if g.sorted != nil {
+		return g.sorted.get(word)
+	}
+
+	return g.WordGraph[word]
+}
+
+// Add a node for a word that isn't in the graph yet
+func (g *WordGraphOfSameLength) putNode(node *WordNode) {
+	if g.sorted != nil {
+		g.sorted.add(node)
+		return
+	}
+
+	// A zero subgraph rather than one from NewWordGraphOfSameLength
+	if g.WordGraph == nil {
+		g.WordGraph = make(map[string]*WordNode)
+	}
+
+	g.WordGraph[node.Word] = node
+}
+
+// Take a word's node out of the graph, if it's there
+func (g *WordGraphOfSameLength) deleteNode(word string) {
+	if g.sorted != nil {
+		g.sorted.remove(word)
+		return
+	}
+
+	delete(g.WordGraph, word)
+}
+
+// Call fn with every node, in no particular order
+func (g *WordGraphOfSameLength) eachNode(fn func(v *WordNode)) {
+	if g.sorted == nil {
+		for _, v := range g.WordGraph {
+			fn(v)
+		}
+
+		return
+	}
+
+	for _, v := range g.sorted.nodes {
+		fn(v)
+	}
+
+	for _, v := range g.sorted.pending {
+		fn(v)
+	}
+}
+
+// Move the words into the given kind of storage, if they aren't there already
+func (g *WordGraphOfSameLength) useStorage(kind StorageKind) {
+	switch {
+	case kind == SortedStorage && g.sorted == nil:
+		g.sorted = &sortedWords{nodes: make([]*WordNode, 0, len(g.WordGraph))}
+
+		for _, v := range g.WordGraph {
+			g.sorted.nodes = append(g.sorted.nodes, v)
+		}
+		sortNodes(g.sorted.nodes)
+
+		g.WordGraph = nil
+	case kind == MapStorage && g.sorted != nil:
+		g.WordGraph = g.wordMap()
+		g.sorted = nil
+	}
+}
+
+// The words as a map, like the WordGraph field.  With SortedStorage it's built fresh each time, for
+// writing JSON and the like.
+func (g *WordGraphOfSameLength) wordMap() map[string]*WordNode {
+	if g.sorted == nil {
+		return g.WordGraph
+	}
+
+	var retval = make(map[string]*WordNode, g.GetTotalWords())
+	g.eachNode(func(v *WordNode) {
+		retval[v.Word] = v
+	})
+
+	return retval
+}
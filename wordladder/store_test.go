@@ -0,0 +1,177 @@
+package wordladder
+
+import (
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"testing"
+)
+
+func TestSortedWords(t *testing.T) {
+	var r = rand.New(rand.NewSource(1))
+	var s = &sortedWords{}
+	var want = make(map[string]*WordNode)
+
+	// Enough words to merge several times, removing some along the way
+	for _, word := range randomWords(3*pendingLimit+17, 3, 2) {
+		if want[word] != nil {
+			continue
+		}
+
+		var node = &WordNode{Word: word}
+		s.add(node)
+		want[word] = node
+
+		if r.Intn(5) == 0 {
+			for other := range want {
+				s.remove(other)
+				delete(want, other)
+				break
+			}
+		}
+	}
+
+	for word, node := range want {
+		if got := s.get(word); got != node {
+			t.Errorf("get(%v): got %v, expected %v", word, got, node)
+		}
+	}
+
+	if got := s.get("zzz"); got != nil {
+		t.Errorf("get(zzz): got %v, expected nil", got)
+	}
+
+	s.compact()
+
+	if len(s.nodes) != len(want) || len(s.pending) != 0 {
+		t.Errorf("got %v sorted and %v pending words after compacting, expected %v and 0", len(s.nodes), len(s.pending), len(want))
+	}
+
+	if !sort.SliceIsSorted(s.nodes, func(i, j int) bool { return s.nodes[i].Word < s.nodes[j].Word }) {
+		t.Errorf("words aren't sorted after compacting")
+	}
+}
+
+func TestStorageKinds(t *testing.T) {
+	var words = randomWords(3000, 4, 3)
+
+	var build = func(kind StorageKind) *WordGraph {
+		var g = NewWordGraphWithStorage(kind)
+		g.UseWildcardIndex = true
+		for _, word := range words {
+			g.AddWord(word)
+		}
+		g.ExploreForests()
+		return g
+	}
+
+	var tests = []struct {
+		name  string
+		query func(g *WordGraph) interface{}
+	}{
+		{"words", func(g *WordGraph) interface{} { return g.Graphs[4].Words() }},
+		{"forests", func(g *WordGraph) interface{} { return g.ForestSizeHistogram(4) }},
+		{"edges", func(g *WordGraph) interface{} { return g.Stats().TotalEdges }},
+		{"paths", func(g *WordGraph) interface{} {
+			var retval = [][]string{}
+			for i := 0; i+1 < 200; i += 2 {
+				retval = append(retval, g.ShortestPath(words[i], words[i+1]))
+			}
+			return retval
+		}},
+		{"isolated", func(g *WordGraph) interface{} { return g.IsolatedWords() }},
+		{"remove and add", func(g *WordGraph) interface{} {
+			g.RemoveWord(words[0])
+			g.AddWordAndLink("zzzz")
+			g.AddWord("aaaa")
+			return []interface{}{g.Contains(words[0]), g.Contains("zzzz"), g.GetTotalWords(), g.Graphs[4].Words(), g.ForestSizeHistogram(4)}
+		}},
+		{"validate", func(g *WordGraph) interface{} { return g.Validate() }},
+	}
+
+	var mapped, sorted = build(MapStorage), build(SortedStorage)
+
+	if sorted.Graphs[4].WordGraph != nil || sorted.Graphs[4].sorted == nil {
+		t.Fatalf("words aren't kept sorted")
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got, want := test.query(sorted), test.query(mapped); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v with SortedStorage, expected %v", got, want)
+			}
+		})
+	}
+}
+
+func TestSortedStorageSaveLoad(t *testing.T) {
+	var g = NewWordGraphWithStorage(SortedStorage)
+	for _, word := range fixtureCatDog {
+		g.AddWord(word)
+	}
+	g.ExploreForests()
+
+	var path = filepath.Join(t.TempDir(), "three.json")
+	if err := g.Graphs[3].Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSubgraph(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loaded.Words(), g.Graphs[3].Words()) {
+		t.Errorf("got %v, expected %v", loaded.Words(), g.Graphs[3].Words())
+	}
+
+	// Adding it to a sorted graph sorts it too
+	var other = NewWordGraphWithStorage(SortedStorage)
+	other.AddSubgraph(loaded)
+
+	if loaded.sorted == nil {
+		t.Errorf("loaded subgraph wasn't moved to sorted storage")
+	}
+
+	if got := other.ShortestPath("cat", "dog"); !samePath(got, []string{"cat", "cot", "cog", "dog"}) {
+		t.Errorf("got %v, expected [cat cot cog dog]", got)
+	}
+}
+
+// Memory a word for each kind of storage, on 100k words (run with -bench Storage)
+func BenchmarkStorage(b *testing.B) {
+	var words = randomWords(100000, 6, 4)
+
+	for _, kind := range []struct {
+		name string
+		kind StorageKind
+	}{
+		{"map", MapStorage},
+		{"sorted", SortedStorage},
+	} {
+		b.Run(kind.name, func(b *testing.B) {
+			var perWord float64
+
+			for i := 0; i < b.N; i++ {
+				var before = heapInUse()
+
+				var g = NewWordGraphWithStorage(kind.kind)
+				g.UseWildcardIndex = true
+				for _, word := range words {
+					g.AddWord(word)
+				}
+				g.ExploreForests()
+
+				// The index is only needed while exploring
+				g.Graphs[6].wildcardIndex = nil
+
+				perWord = float64(heapInUse()-before) / float64(g.GetTotalWords())
+				runtime.KeepAlive(g)
+			}
+
+			b.ReportMetric(perWord, "bytes/word")
+		})
+	}
+}
@@ -0,0 +1,783 @@
+package wordladder
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+/**
+ * A node in the graph (represents a word and its neighbors).
+ *
+ * In JSON it's {"word": "cat", "forest": 1, "neighbors": ["bat", "cot"]}, with the neighbors always a
+ * list of strings (see MarshalJSON).
+ */
+type WordNode struct {
+	Word      string    `json:"word"`      // the word itself
+	ForestTag int       `json:"forest"`    // what forest the word lives in
+	Neighbors []*string `json:"neighbors"` // list of one-character neighbors
+}
+
+/**
+ * A set of forests of words of all the same length.
+ */
+type WordGraphOfSameLength struct {
+	CurForest            int                    // Next forest tag to assign.  Forest tags are not unique across different word lengths
+	WordLength           int                    // Length of words in this group
+	WordGraph            map[string]*WordNode   // Map of words in the graph.  Nil with SortedStorage, see WordGraph.Storage
+	Rules                EdgeRules              // Which moves besides single-letter substitution count as edges
+	Directed             bool                   // Edges only go one way: Neighbors are the words a word can change into
+	NeighborFunc         func(a, b string) bool `json:"-"` // Custom rule for which words are neighbors, nil for one letter apart
+	Alphabet             []rune                 `json:"-"` // Letters to try when making up words (see ShortestPathWithBudget), nil for a-z
+	UseWildcardIndex     bool                   `json:"-"` // Find neighbors through wildcardIndex rather than scanning every word
+	MaxNeighborsExplored int                    `json:"-"` // If set, ShortestPath expands at most this many neighbors per word (best effort)
+	LazyNeighbors        bool                   // Work out neighbors whenever they're needed rather than storing them (see neighborsOf)
+	wildcardIndex        map[string][]*string   // Wildcard pattern ("c_t") to the words matching it, built on demand
+	deletionIndex        map[string][]*string   // One-letter-shorter word to the words it can grow into, built on demand
+	anagramIndex         map[string][]*string   // Sorted-letter signature to the words spelled with those letters, built on demand
+	progress             func(done, total int)  // Told how far along ExploreAllForests is, if set
+	expanded             func()                 // Called for each word ShortestPath or ShortestPathAStar expands, if set (for benchmarks)
+	hasCustomLinks       bool                   // Link has joined words more than one change apart
+	forestsOnly          bool                   // Keep nothing but forest tags after exploring (WordGraph.ForestsOnly)
+	exploredWith         edgeSettings           // What decided the neighbors last time ExploreAllForests ran
+	sorted               *sortedWords           // The words with SortedStorage, instead of the WordGraph map
+}
+
+// Everything that decides which words are neighbors, to tell when stored neighbors are out of date
+type edgeSettings struct {
+	rules        EdgeRules
+	directed     bool
+	neighborFunc uintptr // Which function NeighborFunc is, 0 for nil (a closure's captured state isn't compared)
+}
+
+// The settings the neighbors would be worked out with now
+func (g *WordGraphOfSameLength) edgeSettings() edgeSettings {
+	return edgeSettings{rules: g.Rules, directed: g.Directed, neighborFunc: reflect.ValueOf(g.NeighborFunc).Pointer()}
+}
+
+// How many words ExploreAllForests gets through between progress reports
+const progressInterval = 1000
+
+// Initialize
+func NewWordGraphOfSameLength(len int) *WordGraphOfSameLength {
+	return &WordGraphOfSameLength{CurForest: 1, WordLength: len, WordGraph: make(map[string]*WordNode)}
+}
+
+// Add a word to the graph.  Errors (wrapping ErrLengthMismatch) if the word is the wrong length.
+// Adding a word that's already there does nothing.
+func (g *WordGraphOfSameLength) AddWord(word string) error {
+	if wordLength(word) != g.WordLength {
+		return fmt.Errorf("can't add %q to the %v-letter words: %w", word, g.WordLength, ErrLengthMismatch)
+	}
+
+	if g.node(word) != nil {
+		// Keep the existing node, it may already be linked up
+		return nil
+	}
+
+	// A zero subgraph rather than one from NewWordGraphOfSameLength
+	if g.CurForest < 1 {
+		g.CurForest = 1
+	}
+
+	g.putNode(&WordNode{Word: word, ForestTag: 0, Neighbors: nil})
+	g.wildcardIndex = nil
+	g.deletionIndex = nil
+	g.anagramIndex = nil
+
+	return nil
+}
+
+func (g *WordGraphOfSameLength) GetTotalWords() int {
+	if g.sorted != nil {
+		return len(g.sorted.nodes) + len(g.sorted.pending)
+	}
+
+	return len(g.WordGraph)
+}
+
+// All the words in the graph, sorted alphabetically.
+func (g *WordGraphOfSameLength) Words() []string {
+	var retval = make([]string, 0, g.GetTotalWords())
+
+	g.eachNode(func(v *WordNode) {
+		retval = append(retval, v.Word)
+	})
+
+	sort.Strings(retval)
+
+	return retval
+}
+
+// Count the forests in use.  Words can be linked and removed after exploring, which merges and
+// retires tags, so this counts the distinct tags rather than trusting CurForest.
+func (g *WordGraphOfSameLength) GetTotalForests() int {
+	var retval = 0
+
+	for tag := range g.ForestSizes() {
+		if tag > 0 {
+			retval++
+		}
+	}
+
+	return retval
+}
+
+// Figure out the neighbors of a node, using whichever strategy is configured, plus any extra
+// edges allowed by the rules.  A custom NeighborFunc can only be checked pair by pair, so it always
+// scans, which makes exploring O(n²) in the number of words -- slow on big dictionaries.  It should be
+// symmetric (a is b's neighbor exactly when b is a's), since edges are stored both ways.
+// The neighbors are appended to retval, so exploring can reuse one buffer for every word.
+func (g *WordGraphOfSameLength) figureOutNeighbors(node *WordNode, retval []*string) []*string {
+	if (g.UseWildcardIndex || g.LazyNeighbors) && g.NeighborFunc == nil {
+		retval = g.figureOutNeighborsByIndex(node, retval)
+	} else {
+		retval = g.figureOutNeighborsByScan(node, retval)
+	}
+
+	if g.Rules.Anagrams {
+		for _, word := range g.figureOutAnagrams(node) {
+			// A custom rule may have found it already
+			if g.NeighborFunc == nil || !g.NeighborFunc(node.Word, *word) {
+				retval = append(retval, word)
+			}
+		}
+	}
+
+	return retval
+}
+
+// The neighbors of a node.  Normally that's its Neighbors list, but with LazyNeighbors nothing is
+// stored and they're worked out again on every call, sorted like an explored list would be.  That
+// keeps memory down to the words and the wildcard index on big dictionaries, at the cost of redoing
+// the lookups on each visit (a custom NeighborFunc has to scan every word each time, so it's slow).
+func (g *WordGraphOfSameLength) neighborsOf(node *WordNode) []*string {
+	if !g.LazyNeighbors {
+		return node.Neighbors
+	}
+
+	var retval = g.figureOutNeighbors(node, []*string{})
+	slices.SortFunc(retval, func(a, b *string) int { return strings.Compare(*a, *b) })
+
+	return retval
+}
+
+// Are two words neighbors, by the NeighborFunc if there is one?  A word is never its own neighbor.
+func (g *WordGraphOfSameLength) isNeighbor(s1 string, s2 string) bool {
+	if g.NeighborFunc != nil {
+		return s1 != s2 && g.NeighborFunc(s1, s2)
+	}
+
+	return areNeighbors(s1, s2)
+}
+
+// Figure out the neighbors of a node by filtering the word list, rather than by generation of all possible words.
+// Should be faster depending on length of word and size of dictionary.
+func (g *WordGraphOfSameLength) figureOutNeighborsByScan(node *WordNode, retval []*string) []*string {
+	g.eachNode(func(v *WordNode) {
+		if g.isNeighbor(node.Word, v.Word) {
+			// v is a *WordNode, so this points at the node's own Word, not the loop variable.
+			// Safe under the pre-1.22 loop semantics too.
+			retval = append(retval, &v.Word)
+		}
+	})
+
+	return retval
+}
+
+// Figure out the neighbors of a node by looking up each of its wildcard patterns.
+// Gives the same neighbors as figureOutNeighborsByScan, without the O(n) scan.
+func (g *WordGraphOfSameLength) figureOutNeighborsByIndex(node *WordNode, retval []*string) []*string {
+	if g.wildcardIndex == nil {
+		g.buildWildcardIndex()
+	}
+
+	// Patterns are built in place and looked up without converting to a string, which doesn't allocate
+	var buffer [32]byte
+	var pattern = buffer[:0]
+
+	for i := 0; i < wordLength(node.Word); i++ {
+		pattern = appendWildcardPattern(pattern[:0], node.Word, i)
+
+		for _, word := range g.wildcardIndex[string(pattern)] {
+			// The word matches all of its own patterns, but isn't its own neighbor
+			if *word == node.Word {
+				continue
+			}
+
+			retval = append(retval, word)
+		}
+	}
+
+	return retval
+}
+
+// Bucket every word under each of its wildcard patterns.  The patterns are numbered and counted first,
+// so every bucket can be cut from one list instead of growing on its own, and patterns are only looked
+// up (which doesn't allocate) after their first appearance.
+func (g *WordGraphOfSameLength) buildWildcardIndex() {
+	var ids = make(map[string]int, g.GetTotalWords()*g.WordLength)
+	var counts = []int{}
+	var pattern []byte
+
+	g.eachNode(func(v *WordNode) {
+		for i := 0; i < wordLength(v.Word); i++ {
+			pattern = appendWildcardPattern(pattern[:0], v.Word, i)
+
+			var id, seen = ids[string(pattern)]
+			if !seen {
+				id = len(counts)
+				ids[string(pattern)] = id
+				counts = append(counts, 0)
+			}
+
+			counts[id]++
+		}
+	})
+
+	// Where each bucket starts in the list, then fill them in
+	var starts = make([]int, len(counts)+1)
+	for id, count := range counts {
+		starts[id+1] = starts[id] + count
+	}
+
+	var words = make([]*string, starts[len(counts)])
+	var filled = make([]int, len(counts))
+
+	g.eachNode(func(v *WordNode) {
+		for i := 0; i < wordLength(v.Word); i++ {
+			pattern = appendWildcardPattern(pattern[:0], v.Word, i)
+
+			var id = ids[string(pattern)]
+			words[starts[id]+filled[id]] = &v.Word
+			filled[id]++
+		}
+	})
+
+	// Each bucket is capped at its own words, so appending to one can't spill into the next
+	g.wildcardIndex = make(map[string][]*string, len(ids))
+	for key, id := range ids {
+		g.wildcardIndex[key] = words[starts[id]:starts[id+1]:starts[id+1]]
+	}
+}
+
+// Explore the entire graph, finding all forests and neighbors.
+// Neighbors are worked out for any word that doesn't have a forest yet, then every word is grouped
+// with its neighbors in a disjoint set and each set becomes a forest.
+// Words are visited in alphabetical order and neighbor lists are sorted, so the same words always end
+// up with the same forest tags and neighbor lists, however the map happens to iterate.
+// If Rules, Directed or NeighborFunc have changed since the last time, every word is worked out again
+// from scratch, and any links made with Link are lost.
+func (g *WordGraphOfSameLength) ExploreAllForests() {
+	if settings := g.edgeSettings(); settings != g.exploredWith {
+		g.eachNode(func(v *WordNode) {
+			v.ForestTag = 0
+			v.Neighbors = nil
+		})
+
+		g.CurForest = 1
+		g.hasCustomLinks = false
+		g.exploredWith = settings
+	}
+
+	var nodes = make([]*WordNode, 0, g.GetTotalWords())
+	g.eachNode(func(v *WordNode) {
+		nodes = append(nodes, v)
+	})
+	sortNodes(nodes)
+
+	var fresh = make(map[*WordNode]bool, len(nodes))
+
+	// Neighbors are worked out in a reused buffer, then copied into shared slabs rather than a list
+	// per word, to keep allocations down.  Each word's slice is capped at its own neighbors, so
+	// appending to one later copies it instead of overwriting the next word's.
+	var buffer []*string
+	var slab []*string
+
+	for i, v := range nodes {
+		if g.progress != nil && (i+1)%progressInterval == 0 && i+1 < len(nodes) {
+			g.progress(i+1, len(nodes))
+		}
+
+		if v.ForestTag <= 0 && !g.LazyNeighbors {
+			// It's unassigned so far, need to figure out its neighbors
+			fresh[v] = true
+			buffer = g.figureOutNeighbors(v, buffer[:0])
+
+			if len(buffer) > cap(slab)-len(slab) {
+				slab = make([]*string, 0, max(neighborSlabSize, len(buffer)))
+			}
+
+			var start = len(slab)
+			slab = append(slab, buffer...)
+			v.Neighbors = slab[start:len(slab):len(slab)]
+
+			slices.SortFunc(v.Neighbors, func(a, b *string) int { return strings.Compare(*a, *b) })
+		}
+	}
+
+	// Words explored earlier don't know about their new neighbors yet
+	for _, v := range nodes {
+		if !fresh[v] {
+			continue
+		}
+
+		for _, neighborWord := range v.Neighbors {
+			var neighbor = g.node(*neighborWord)
+
+			if !fresh[neighbor] {
+				neighbor.Neighbors = append(neighbor.Neighbors, &v.Word)
+			}
+		}
+	}
+
+	g.tagForests(nodes)
+
+	if g.sorted != nil {
+		g.sorted.compact()
+	}
+
+	if g.forestsOnly {
+		// Only the words and their forest tags are kept.  The indexes are built again if neighbors
+		// are asked for later (or by Freeze).
+		for _, v := range nodes {
+			v.Neighbors = nil
+		}
+
+		g.wildcardIndex = nil
+		g.anagramIndex = nil
+	}
+
+	if g.progress != nil {
+		g.progress(len(nodes), g.GetTotalWords())
+	}
+}
+
+// How many neighbors ExploreAllForests allocates room for at a time
+const neighborSlabSize = 1024
+
+// Sort nodes alphabetically by word
+func sortNodes(nodes []*WordNode) {
+	sort.Slice(nodes, func(a, b int) bool { return nodes[a].Word < nodes[b].Word })
+}
+
+// Group nodes into forests by unioning each with its neighbors, then tag every forest.
+// A forest keeps the lowest tag already on one of its words, unless another forest claimed it first
+// (say, after a split); everything else gets a new tag.  New tags are handed out in the order of nodes,
+// so sort them first for the same tags every run.
+func (g *WordGraphOfSameLength) tagForests(nodes []*WordNode) {
+	var forests = newDisjointSet()
+
+	for _, v := range nodes {
+		forests.add(v.Word)
+	}
+
+	for _, v := range nodes {
+		for _, neighborWord := range g.neighborsOf(v) {
+			if forests.contains(*neighborWord) {
+				forests.union(v.Word, *neighborWord)
+			}
+		}
+	}
+
+	// Find the lowest existing tag in each forest
+	var lowest = make(map[string]int)
+
+	for _, v := range nodes {
+		if v.ForestTag > 0 {
+			var root = forests.find(v.Word)
+
+			if lowest[root] == 0 || v.ForestTag < lowest[root] {
+				lowest[root] = v.ForestTag
+			}
+		}
+	}
+
+	// And hand out the tags
+	var rootTags = make(map[string]int)
+	var claimed = make(map[int]bool)
+
+	for _, v := range nodes {
+		var root = forests.find(v.Word)
+
+		var tag, assigned = rootTags[root]
+		if !assigned {
+			tag = lowest[root]
+
+			if tag <= 0 || claimed[tag] {
+				// Move along to the next forest
+				tag = g.CurForest
+				g.CurForest++
+			}
+
+			claimed[tag] = true
+			rootTags[root] = tag
+		}
+
+		v.ForestTag = tag
+	}
+}
+
+// Add a word to an already explored graph, linking it to its neighbors without re-exploring.
+// It joins its neighbors' forest, and if it bridges several forests they're merged into the one
+// with the lowest tag.  With no neighbors it starts a forest of its own.  Existing words are left alone.
+func (g *WordGraphOfSameLength) AddWordAndLink(word string) {
+	if wordLength(word) != g.WordLength || g.node(word) != nil {
+		return
+	}
+
+	g.AddWord(word)
+
+	var node = g.node(word)
+	var neighbors = g.figureOutNeighbors(node, []*string{})
+
+	if !g.LazyNeighbors {
+		node.Neighbors = neighbors
+	}
+
+	// Link it in from the other side, and see which forests it touches
+	var tags = make(map[int]bool)
+	var tag = 0
+
+	for _, neighborWord := range neighbors {
+		var neighbor = g.node(*neighborWord)
+		if !g.LazyNeighbors {
+			neighbor.Neighbors = append(neighbor.Neighbors, &node.Word)
+		}
+
+		if neighbor.ForestTag > 0 {
+			tags[neighbor.ForestTag] = true
+
+			if tag == 0 || neighbor.ForestTag < tag {
+				tag = neighbor.ForestTag
+			}
+		}
+	}
+
+	if tag == 0 {
+		// Nobody to join
+		node.ForestTag = g.CurForest
+		g.CurForest++
+		return
+	}
+
+	node.ForestTag = tag
+
+	// Merge any other forests it bridges into this one
+	if len(tags) > 1 {
+		g.eachNode(func(v *WordNode) {
+			if tags[v.ForestTag] {
+				v.ForestTag = tag
+			}
+		})
+	}
+}
+
+// Join two existing words with an edge, even if they aren't one change apart (for custom links like
+// synonyms), merging their forests if they were apart.  The smaller forest takes the larger one's tag.
+// In a Directed graph the edge only goes from word1 to word2.
+// Forests need to have been explored first, or exploring would throw the link away, and there are no
+// neighbor lists to link with LazyNeighbors.  Errors with ErrWordNotFound if either word is missing.
+func (g *WordGraphOfSameLength) Link(word1 string, word2 string) error {
+	for _, word := range []string{word1, word2} {
+		if g.node(word) == nil {
+			return ErrWordNotFound{Word: word}
+		}
+	}
+
+	var node1, node2 = g.node(word1), g.node(word2)
+
+	if node1.ForestTag <= 0 || node2.ForestTag <= 0 {
+		return fmt.Errorf("can't link %q and %q before forests are explored", word1, word2)
+	}
+
+	if g.LazyNeighbors {
+		return fmt.Errorf("can't link %q and %q with LazyNeighbors, there are no neighbor lists to add to", word1, word2)
+	}
+
+	if word1 == word2 {
+		// A word isn't its own neighbor
+		return nil
+	}
+
+	// Add the edge both ways, unless it's already there
+	if !hasNeighbor(node1.Neighbors, word2) {
+		node1.Neighbors = append(node1.Neighbors, &node2.Word)
+	}
+
+	if !g.Directed && !hasNeighbor(node2.Neighbors, word1) {
+		node2.Neighbors = append(node2.Neighbors, &node1.Word)
+	}
+
+	if !areNeighbors(word1, word2) {
+		// The hamming distance can overestimate now, see ShortestPathAStar
+		g.hasCustomLinks = true
+	}
+
+	if node1.ForestTag == node2.ForestTag {
+		return nil
+	}
+
+	// Merge the smaller forest into the larger
+	var sizes = g.ForestSizes()
+	var from, to = node1.ForestTag, node2.ForestTag
+	if sizes[from] > sizes[to] {
+		from, to = to, from
+	}
+
+	g.eachNode(func(v *WordNode) {
+		if v.ForestTag == from {
+			v.ForestTag = to
+		}
+	})
+
+	return nil
+}
+
+// Remove a word from the graph.  False if the word wasn't there.
+// Its forest is re-tagged, since removing a bridge word can split one forest into several.
+func (g *WordGraphOfSameLength) RemoveWord(word string) bool {
+	var node = g.node(word)
+	if node == nil {
+		return false
+	}
+
+	g.deleteNode(word)
+	g.wildcardIndex = nil
+	g.deletionIndex = nil
+	g.anagramIndex = nil
+
+	if node.ForestTag <= 0 {
+		// Never explored, nothing to fix up
+		return true
+	}
+
+	// Unlink it from its neighbors, and gather up everything that shared the forest
+	var members = []*WordNode{}
+	g.eachNode(func(v *WordNode) {
+		if v.ForestTag != node.ForestTag {
+			return
+		}
+
+		members = append(members, v)
+
+		for i, neighborWord := range v.Neighbors {
+			if *neighborWord == word {
+				v.Neighbors = append(v.Neighbors[:i:i], v.Neighbors[i+1:]...)
+				break
+			}
+		}
+	})
+
+	// Re-tag whatever is left.  The first piece keeps the old tag, any others get new ones.
+	sortNodes(members)
+	g.tagForests(members)
+
+	return true
+}
+
+// Swap one word for another of the same length, say to fix a typo in the dictionary.  The old word
+// is removed (re-tagging its forest, which may split) and the new one linked to its own neighbors
+// (joining and maybe merging their forests).  Any custom links to the old word are lost.  If the graph
+// hasn't been explored yet the new word is just added, to be explored with everything else.
+// Errors with ErrLengthMismatch if the lengths differ, ErrWordNotFound if the old word is missing, or
+// ErrWordExists if the new one is already there, without changing anything.
+func (g *WordGraphOfSameLength) Replace(oldWord string, newWord string) error {
+	if wordLength(oldWord) != wordLength(newWord) {
+		return ErrLengthMismatch
+	}
+
+	var node = g.node(oldWord)
+	if node == nil {
+		return ErrWordNotFound{Word: oldWord}
+	}
+
+	if oldWord == newWord {
+		return nil
+	}
+
+	if g.node(newWord) != nil {
+		return ErrWordExists{Word: newWord}
+	}
+
+	var explored = node.ForestTag > 0
+	g.RemoveWord(oldWord)
+
+	if explored {
+		g.AddWordAndLink(newWord)
+	} else {
+		_ = g.AddWord(newWord)
+	}
+
+	return nil
+}
+
+// Does a path exist between two strings?  O(1) check by looking at matching forest
+// tags (the work was done in pre-processing).
+// Directed graphs need a search as well: forests ignore direction, so sharing one isn't enough.
+func (g *WordGraphOfSameLength) AreTwoWordsConnected(s1 string, s2 string) bool {
+	// Valid words check
+	if g.node(s1) == nil || g.node(s2) == nil {
+		return false
+	}
+
+	if g.node(s1).ForestTag != g.node(s2).ForestTag {
+		return false
+	}
+
+	return !g.Directed || g.reaches(s1, s2)
+}
+
+// Can s1 get to s2 following the edges forwards?  BFS, for directed graphs.
+func (g *WordGraphOfSameLength) reaches(s1 string, s2 string) bool {
+	var visited = map[string]bool{s1: true}
+	var layer = []string{s1}
+
+	for len(layer) > 0 {
+		var nextLayer = []string{}
+
+		for _, word := range layer {
+			if word == s2 {
+				return true
+			}
+
+			for _, neighborWord := range g.neighborsOf(g.node(word)) {
+				if !visited[*neighborWord] {
+					visited[*neighborWord] = true
+					nextLayer = append(nextLayer, *neighborWord)
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return false
+}
+
+// Return a shortest path from s1 to s2.  Nil if no path exists.
+// The path always runs start to end: the first word is s1 and the last is s2.
+// If s1 and s2 are the same dictionary word, the path is just that word.
+// With MaxNeighborsExplored set this is best effort; see ShortestPathLimited.
+// See ShortestPathAStar for a version using a priority queue and hamming distance.
+func (g *WordGraphOfSameLength) ShortestPath(s1 string, s2 string) []string {
+	var retval, _ = g.ShortestPathContext(context.Background(), s1, s2)
+	return retval
+}
+
+// Like ShortestPath, but also says whether MaxNeighborsExplored cut the search short.  If it did,
+// the path may be longer than necessary, or nil even though the words are connected.
+func (g *WordGraphOfSameLength) ShortestPathLimited(s1 string, s2 string) ([]string, bool) {
+	var retval, truncated, _ = g.shortestPath(context.Background(), s1, s2)
+	return retval, truncated
+}
+
+// How many nodes ShortestPathContext explores between checks of its context
+const contextCheckInterval = 1024
+
+// Like ShortestPath, but gives up with ctx.Err() if ctx is cancelled or hits its deadline mid-search
+func (g *WordGraphOfSameLength) ShortestPathContext(ctx context.Context, s1 string, s2 string) ([]string, error) {
+	var retval, _, err = g.shortestPath(ctx, s1, s2)
+	return retval, err
+}
+
+// The BFS behind ShortestPath and friends.  Also returns whether MaxNeighborsExplored skipped any neighbors.
+func (g *WordGraphOfSameLength) shortestPath(ctx context.Context, s1 string, s2 string) ([]string, bool, error) {
+	if !g.AreTwoWordsConnected(s1, s2) {
+		// No path exists
+		return nil, false, nil
+	}
+
+	if s1 == s2 {
+		// Already there, the path is just the word itself
+		return []string{s1}, false, nil
+	}
+
+	// We actually search backwards (s2 -> s1), so we don't have to reverse the string
+	// at the end (since the path is built by following parent links up from the end).
+	// That's what makes the result come out s1 first.  Directed edges can't be followed backwards,
+	// so then we search forwards and reverse it after all.
+	var root, goal = s2, s1
+	if g.Directed {
+		root, goal = s1, s2
+	}
+
+	var visited = make(map[string]bool)
+	var target *WNPathQueueNode = nil
+	var truncated = false
+
+	var q = WNPathQueue{}
+	q.push(&WNPathQueueNode{wn: g.node(root), parent: nil})
+	visited[root] = true
+
+	for explored := 0; ; explored++ {
+		if explored%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, truncated, err
+			}
+		}
+
+		var node = q.pop()
+
+		if node == nil {
+			return nil, truncated, nil
+		} else {
+			// Have we found our target word?
+			if node.wn.Word == goal {
+				target = node // Save to follow the path back up
+				break
+			}
+
+			if g.expanded != nil {
+				g.expanded()
+			}
+
+			var neighbors = g.neighborsOf(node.wn)
+			if g.MaxNeighborsExplored > 0 && len(neighbors) > g.MaxNeighborsExplored {
+				// Too many to look at them all, take the ones that look closest to the target
+				neighbors = closestWords(neighbors, goal, g.MaxNeighborsExplored)
+				truncated = true
+			}
+
+			// check neighbors that haven't been visited
+			for _, neighborWord := range neighbors {
+
+				if !visited[*neighborWord] {
+					visited[*neighborWord] = true
+
+					var neighborNode = g.node(*neighborWord)
+
+					// Add nodes with the parent set
+					q.push(&WNPathQueueNode{wn: neighborNode, parent: node})
+				}
+			}
+		}
+	}
+
+	if target == nil {
+		// Didn't find it.  I'm not sure if this can happen, we should be safe from the areTwoWordsConnected() check
+		return nil, truncated, nil
+	}
+
+	// Build the path back up
+	var retval = []string{}
+
+	var cur = target
+	for {
+		if cur == nil {
+			break
+		}
+
+		retval = append(retval, cur.wn.Word)
+		cur = cur.parent
+	}
+
+	if g.Directed {
+		slices.Reverse(retval)
+	}
+
+	return retval, truncated, nil
+}
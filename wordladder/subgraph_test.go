@@ -0,0 +1,507 @@
+package wordladder
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestLinkConnectsForests(t *testing.T) {
+	var tests = []struct {
+		name     string
+		directed bool
+		s1, s2   string
+		forward  bool
+		backward bool
+	}{
+		{"undirected", false, "cat", "dog", true, true},
+		{"directed", true, "cat", "dog", true, false},
+		{"already connected", false, "cat", "cot", true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphOfSameLength(3)
+			g.Directed = test.directed
+			for _, word := range []string{"cat", "cot", "dog", "dig"} {
+				g.AddWord(word)
+			}
+			g.ExploreAllForests()
+
+			if err := g.Link(test.s1, test.s2); err != nil {
+				t.Fatal(err)
+			}
+
+			if !g.AreTwoWordsConnected(test.s1, test.s2) {
+				t.Errorf("%v and %v should be in the same forest after linking", test.s1, test.s2)
+			}
+
+			if got := hasNeighbor(g.WordGraph[test.s1].Neighbors, test.s2); got != test.forward {
+				t.Errorf("%v -> %v edge: got %v, expected %v", test.s1, test.s2, got, test.forward)
+			}
+
+			if got := hasNeighbor(g.WordGraph[test.s2].Neighbors, test.s1); got != test.backward {
+				t.Errorf("%v -> %v edge: got %v, expected %v", test.s2, test.s1, got, test.backward)
+			}
+
+			if got := g.GetTotalForests(); test.s1 == "cat" && test.s2 == "dog" && got != 1 {
+				t.Errorf("got %v forests after linking, expected 1", got)
+			}
+		})
+	}
+}
+
+func TestLinkErrors(t *testing.T) {
+	var g = NewWordGraphOfSameLength(3)
+	g.AddWord("cat")
+	g.AddWord("dog")
+
+	if err := g.Link("cat", "dog"); err == nil {
+		t.Errorf("linking before exploring should fail")
+	}
+
+	g.ExploreAllForests()
+
+	if err := g.Link("cat", "cow"); err != (ErrWordNotFound{Word: "cow"}) {
+		t.Errorf("got %v, expected ErrWordNotFound for cow", err)
+	}
+}
+
+func TestShortestPathOnCycle(t *testing.T) {
+	// cat-cot-cog-bog-bot-bat-cat goes round in a loop, with cot-bot across the middle
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "cog", "bog", "bot", "bat"}).Graphs[3]
+
+	var tests = []struct {
+		s1, s2 string
+		steps  int
+	}{
+		{"cat", "cat", 0},
+		{"cat", "cot", 1},
+		{"cat", "bog", 3},
+		{"cog", "bat", 3},
+		{"bat", "cog", 3},
+		{"cot", "bot", 1},
+	}
+
+	for _, test := range tests {
+		var path = g.ShortestPath(test.s1, test.s2)
+
+		if len(path) != test.steps+1 {
+			t.Errorf("%v -> %v: got %v, expected %v steps", test.s1, test.s2, path, test.steps)
+			continue
+		}
+
+		var seen = make(map[string]bool)
+		for i, word := range path {
+			if seen[word] {
+				t.Errorf("%v -> %v: %v visits %v twice", test.s1, test.s2, path, word)
+			}
+			seen[word] = true
+
+			if i > 0 && !areNeighbors(path[i-1], word) {
+				t.Errorf("%v -> %v: %v jumps from %v to %v", test.s1, test.s2, path, path[i-1], word)
+			}
+		}
+
+		if path[0] != test.s1 || path[len(path)-1] != test.s2 {
+			t.Errorf("%v -> %v: got %v", test.s1, test.s2, path)
+		}
+	}
+}
+
+// An unexplored subgraph of the given words, finding neighbors with or without the wildcard index
+func newSubgraph(words []string, useIndex bool) *WordGraphOfSameLength {
+	var g = NewWordGraphOfSameLength(wordLength(words[0]))
+	g.UseWildcardIndex = useIndex
+
+	for _, word := range words {
+		g.AddWord(word)
+	}
+
+	return g
+}
+
+func TestWildcardIndexMatchesScan(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+	}{
+		{"cat-dog", fixtureCatDog},
+		{"cold-warm", fixtureColdWarm},
+		{"random", randomWords(2000, 4, 7)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var scan, index = newSubgraph(test.words, false), newSubgraph(test.words, true)
+			scan.ExploreAllForests()
+			index.ExploreAllForests()
+
+			for _, word := range scan.Words() {
+				var want, got = scan.node(word), index.node(word)
+
+				if got.ForestTag != want.ForestTag {
+					t.Errorf("%v: got forest %v, expected %v", word, got.ForestTag, want.ForestTag)
+				}
+
+				if !samePath(wordsOf(got.Neighbors), wordsOf(want.Neighbors)) {
+					t.Errorf("%v: got neighbors %v, expected %v", word, wordsOf(got.Neighbors), wordsOf(want.Neighbors))
+				}
+			}
+		})
+	}
+}
+
+// The words a list of neighbors points at
+func wordsOf(neighbors []*string) []string {
+	var retval = make([]string, len(neighbors))
+	for i, neighborWord := range neighbors {
+		retval[i] = *neighborWord
+	}
+	return retval
+}
+
+// Exploring 5000 words by scanning every pair, and with the wildcard index (run with -bench ExploreAllForests)
+func BenchmarkExploreAllForests(b *testing.B) {
+	var words = randomWords(5000, 5, 8)
+
+	for _, strategy := range []struct {
+		name     string
+		useIndex bool
+	}{
+		{"scan", false},
+		{"index", true},
+	} {
+		b.Run(strategy.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				var g = newSubgraph(words, strategy.useIndex)
+				b.StartTimer()
+
+				g.ExploreAllForests()
+			}
+		})
+	}
+}
+
+func TestShortestPathContext(t *testing.T) {
+	var g = NewWordGraphFromWords(fixtureCatDog).Graphs[3]
+
+	var cancelled, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	var expired, cancelExpired = context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	var tests = []struct {
+		name string
+		ctx  context.Context
+		path []string
+		err  error
+	}{
+		{"background", context.Background(), []string{"cat", "cot", "cog", "dog"}, nil},
+		{"cancelled", cancelled, nil, context.Canceled},
+		{"past deadline", expired, nil, context.DeadlineExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var path, err = g.ShortestPathContext(test.ctx, "cat", "dog")
+
+			if err != test.err {
+				t.Errorf("got error %v, expected %v", err, test.err)
+			}
+
+			if !samePath(path, test.path) {
+				t.Errorf("got %v, expected %v", path, test.path)
+			}
+		})
+	}
+}
+
+func TestShortestPathSameWord(t *testing.T) {
+	var g = NewWordGraphFromWords([]string{"cat", "cot", "xyz"}).Graphs[3]
+
+	var tests = []struct {
+		word string
+		path []string
+	}{
+		{"cat", []string{"cat"}},
+		{"xyz", []string{"xyz"}},
+		{"cut", nil},
+	}
+
+	for _, test := range tests {
+		if got := g.ShortestPath(test.word, test.word); !samePath(got, test.path) || (test.path == nil && got != nil) {
+			t.Errorf("ShortestPath(%v, %v): got %v, expected %v", test.word, test.word, got, test.path)
+		}
+	}
+}
+
+func TestAddWordAndLink(t *testing.T) {
+	var tests = []struct {
+		name    string
+		word    string
+		forests int
+		joined  []string // Words that should share a forest with the new one
+	}{
+		// cot bridges the cat forest and the dog-dot forest
+		{"merges two forests", "cot", 2, []string{"cat", "dog", "dot"}},
+		{"joins one forest", "bat", 3, []string{"cat"}},
+		{"starts its own forest", "fig", 4, nil},
+		{"already there", "cat", 3, []string{"cat"}},
+		{"wrong length", "cold", 3, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphOfSameLength(3)
+			for _, word := range []string{"cat", "dog", "dot", "pen"} {
+				g.AddWord(word)
+			}
+			g.ExploreAllForests()
+
+			g.AddWordAndLink(test.word)
+
+			if got := g.GetTotalForests(); got != test.forests {
+				t.Errorf("got %v forests, expected %v", got, test.forests)
+			}
+
+			for _, word := range test.joined {
+				if !g.AreTwoWordsConnected(test.word, word) {
+					t.Errorf("%v should be connected to %v", test.word, word)
+				}
+
+				if areNeighbors(test.word, word) && !hasNeighbor(g.WordGraph[word].Neighbors, test.word) {
+					t.Errorf("%v should have been linked in to %v's neighbors", test.word, word)
+				}
+			}
+
+			if g.AreTwoWordsConnected(test.word, "pen") {
+				t.Errorf("%v shouldn't be connected to pen", test.word)
+			}
+		})
+	}
+}
+
+func TestAddWordLengthMismatch(t *testing.T) {
+	var tests = []struct {
+		word string
+		err  error
+	}{
+		{"cat", nil},
+		{"cat", nil}, // Again, which does nothing
+		{"café", ErrLengthMismatch},
+		{"at", ErrLengthMismatch},
+		{"", ErrLengthMismatch},
+		{"żuk", nil}, // Three letters, though more bytes
+	}
+
+	var g = NewWordGraphOfSameLength(3)
+
+	for _, test := range tests {
+		if err := g.AddWord(test.word); !errors.Is(err, test.err) {
+			t.Errorf("AddWord(%q): got %v, expected %v", test.word, err, test.err)
+		}
+	}
+
+	if got := g.Words(); !reflect.DeepEqual(got, []string{"cat", "żuk"}) {
+		t.Errorf("got words %v, expected [cat żuk]", got)
+	}
+}
+
+func TestReplace(t *testing.T) {
+	var notFound ErrWordNotFound
+
+	var tests = []struct {
+		name     string
+		old, new string
+		err      error
+		forests  int
+		joined   [][2]string // Pairs that should be connected afterwards
+		split    [][2]string // Pairs that shouldn't
+	}{
+		// cot is the only bridge from cat to cog and dog
+		{"splits at a bridge", "cot", "pin", nil, 3, [][2]string{{"cog", "dog"}}, [][2]string{{"cat", "dog"}, {"cat", "pin"}, {"pin", "cog"}}},
+		{"already there", "cot", "cat", ErrWordExists{Word: "cat"}, 1, [][2]string{{"cat", "dog"}}, nil}, // Nothing removed
+		{"another bridge", "cot", "cag", nil, 1, [][2]string{{"cat", "dog"}, {"cag", "cog"}}, nil},
+		{"same word", "cot", "cot", nil, 1, [][2]string{{"cat", "dog"}}, nil},
+		{"missing", "cut", "pin", notFound, 1, [][2]string{{"cat", "dog"}}, nil},
+		{"wrong length", "cot", "coat", ErrLengthMismatch, 1, [][2]string{{"cat", "dog"}}, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = newSubgraph([]string{"cat", "cot", "cog", "dog"}, false)
+			g.ExploreAllForests()
+
+			var err = g.Replace(test.old, test.new)
+			switch test.err.(type) {
+			case nil:
+				if err != nil {
+					t.Fatalf("got error %v", err)
+				}
+			case ErrWordNotFound:
+				if !errors.As(err, &notFound) || notFound.Word != test.old {
+					t.Errorf("got error %v, expected %v not found", err, test.old)
+				}
+			case ErrWordExists:
+				if err != test.err {
+					t.Errorf("got error %v, expected %v", err, test.err)
+				}
+			default:
+				if !errors.Is(err, test.err) {
+					t.Errorf("got error %v, expected %v", err, test.err)
+				}
+			}
+
+			if got := g.GetTotalForests(); got != test.forests {
+				t.Errorf("got %v forests, expected %v", got, test.forests)
+			}
+
+			if err == nil && test.old != test.new && (g.node(test.old) != nil || g.node(test.new) == nil) {
+				t.Errorf("got words %v, expected %v swapped for %v", g.Words(), test.old, test.new)
+			}
+
+			if err != nil && g.GetTotalWords() != 4 {
+				t.Errorf("got words %v after an error, expected all 4 left alone", g.Words())
+			}
+
+			for _, pair := range test.joined {
+				if !g.AreTwoWordsConnected(pair[0], pair[1]) {
+					t.Errorf("%v should be connected to %v", pair[0], pair[1])
+				}
+			}
+
+			for _, pair := range test.split {
+				if g.AreTwoWordsConnected(pair[0], pair[1]) {
+					t.Errorf("%v shouldn't be connected to %v", pair[0], pair[1])
+				}
+			}
+
+			// Nothing should still point at the old word
+			g.eachNode(func(v *WordNode) {
+				if err == nil && test.old != test.new && hasNeighbor(v.Neighbors, test.old) {
+					t.Errorf("%v still has %v as a neighbor", v.Word, test.old)
+				}
+			})
+		})
+	}
+}
+
+func TestNeighborPointers(t *testing.T) {
+	var tests = []struct {
+		name     string
+		words    []string
+		useIndex bool
+	}{
+		{"cat-dog scan", fixtureCatDog, false},
+		{"cat-dog index", fixtureCatDog, true},
+		{"cold-warm scan", fixtureColdWarm, false},
+		{"random index", randomWords(1000, 3, 3), true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = newSubgraph(test.words, test.useIndex)
+			g.ExploreAllForests()
+
+			var words = g.Words()
+
+			for _, word := range words {
+				var node = g.node(word)
+
+				// Worked out the slow way, for comparison
+				var want = []string{}
+				for _, other := range words {
+					if areNeighbors(word, other) {
+						want = append(want, other)
+					}
+				}
+
+				var got = wordsOf(node.Neighbors)
+				sort.Strings(got)
+
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("%v: got neighbors %v, expected %v", word, got, want)
+				}
+
+				// Each one points at its neighbor's own Word, not a shared or reused variable
+				var seen = make(map[*string]bool)
+				for _, neighborWord := range node.Neighbors {
+					if seen[neighborWord] {
+						t.Errorf("%v: %v is in its neighbors twice", word, *neighborWord)
+					}
+					seen[neighborWord] = true
+
+					if neighborWord != &g.node(*neighborWord).Word {
+						t.Errorf("%v: neighbor %v doesn't point at that node's Word", word, *neighborWord)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestEmptySubgraph(t *testing.T) {
+	var subgraphs = []struct {
+		name string
+		g    *WordGraphOfSameLength
+	}{
+		{"new", NewWordGraphOfSameLength(3)},
+		{"zero", &WordGraphOfSameLength{}},
+	}
+
+	for _, test := range subgraphs {
+		t.Run(test.name, func(t *testing.T) {
+			var g = test.g
+			g.ExploreAllForests()
+
+			if g.GetTotalWords() != 0 || g.GetTotalForests() != 0 || g.EdgeCount() != 0 || g.AverageDegree() != 0 {
+				t.Errorf("got %v words, %v forests, %v edges", g.GetTotalWords(), g.GetTotalForests(), g.EdgeCount())
+			}
+
+			if g.ShortestPath("cat", "dog") != nil || g.ShortestPathAStar("cat", "dog") != nil || g.ShortestPathBidirectional("cat", "dog") != nil {
+				t.Errorf("expected no paths")
+			}
+
+			if len(g.Words()) != 0 || len(g.IsolatedWords()) != 0 || len(g.MostConnectedWords(3)) != 0 || len(g.ForestSizes()) != 0 {
+				t.Errorf("expected no words")
+			}
+
+			if word, _ := g.NearestWord("cat"); word != "" {
+				t.Errorf("NearestWord: got %v", word)
+			}
+		})
+	}
+}
+
+// Allocations exploring 8000 words, with and without the wildcard index (run with -bench ExploreAllocs).
+// Neighbor lists come out of shared slabs, so the scan's allocs/op stay in the hundreds; most of the
+// index's are the index itself.
+func BenchmarkExploreAllocs(b *testing.B) {
+	var words = randomWords(8000, 4, 88)
+
+	for _, strategy := range []struct {
+		name     string
+		useIndex bool
+	}{
+		{"scan", false},
+		{"index", true},
+	} {
+		b.Run(strategy.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				var g = newSubgraph(words, strategy.useIndex)
+				b.StartTimer()
+
+				g.ExploreAllForests()
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package wordladder
+
+// A disjoint set (union-find) over words, with path compression and union by rank.
+// For grouping words into forests.
+type disjointSet struct {
+	parent map[string]string // parent of each word, roots are their own parent
+	rank   map[string]int    // upper bound on the height of each root's tree
+}
+
+func newDisjointSet() *disjointSet {
+	return &disjointSet{parent: make(map[string]string), rank: make(map[string]int)}
+}
+
+// Start a word off in a set of its own
+func (s *disjointSet) add(word string) {
+	if _, present := s.parent[word]; !present {
+		s.parent[word] = word
+	}
+}
+
+func (s *disjointSet) contains(word string) bool {
+	_, present := s.parent[word]
+	return present
+}
+
+// The representative of word's set, flattening the path to it along the way
+func (s *disjointSet) find(word string) string {
+	var root = word
+	for s.parent[root] != root {
+		root = s.parent[root]
+	}
+
+	for word != root {
+		var next = s.parent[word]
+		s.parent[word] = root
+		word = next
+	}
+
+	return root
+}
+
+// Merge the sets containing a and b
+func (s *disjointSet) union(a string, b string) {
+	var ra, rb = s.find(a), s.find(b)
+	if ra == rb {
+		return
+	}
+
+	// Hang the shorter tree off the taller one
+	if s.rank[ra] < s.rank[rb] {
+		ra, rb = rb, ra
+	}
+
+	s.parent[rb] = ra
+
+	if s.rank[ra] == s.rank[rb] {
+		s.rank[ra]++
+	}
+}
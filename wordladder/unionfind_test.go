@@ -0,0 +1,97 @@
+package wordladder
+
+import (
+	"testing"
+)
+
+func TestDisjointSet(t *testing.T) {
+	var s = newDisjointSet()
+	for _, word := range []string{"a", "b", "c", "d", "e"} {
+		s.add(word)
+	}
+
+	s.union("a", "b")
+	s.union("c", "d")
+	s.union("b", "d")
+
+	var tests = []struct {
+		a, b string
+		same bool
+	}{
+		{"a", "a", true},
+		{"a", "b", true},
+		{"a", "d", true},
+		{"b", "c", true},
+		{"a", "e", false},
+		{"d", "e", false},
+	}
+
+	for _, test := range tests {
+		if got := s.find(test.a) == s.find(test.b); got != test.same {
+			t.Errorf("%v and %v in the same set: got %v, expected %v", test.a, test.b, got, test.same)
+		}
+	}
+
+	if s.contains("f") {
+		t.Errorf("f was never added")
+	}
+}
+
+// Flood fill from each word, the way forests were found before union-find, for comparison
+func floodForests(words []string) map[string]int {
+	var retval = make(map[string]int)
+	var tag = 0
+
+	for _, start := range words {
+		if retval[start] > 0 {
+			continue
+		}
+
+		tag++
+		retval[start] = tag
+
+		var queue = []string{start}
+		for len(queue) > 0 {
+			var word = queue[0]
+			queue = queue[1:]
+
+			for _, other := range words {
+				if retval[other] == 0 && len(other) == len(word) && areNeighbors(word, other) {
+					retval[other] = tag
+					queue = append(queue, other)
+				}
+			}
+		}
+	}
+
+	return retval
+}
+
+func TestForestsMatchFloodFill(t *testing.T) {
+	var tests = []struct {
+		name  string
+		words []string
+	}{
+		{"cat dog", fixtureCatDog},
+		{"cold warm", fixtureColdWarm},
+		{"mixed", fixtureMixed},
+		{"random", randomWords(500, 3, 1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(test.words)
+			var flooded = floodForests(test.words)
+
+			for _, a := range test.words {
+				for _, b := range test.words {
+					var expected = len(a) == len(b) && flooded[a] == flooded[b]
+
+					if got := g.AreTwoWordsConnected(a, b); got != expected {
+						t.Fatalf("%v and %v connected: got %v, expected %v", a, b, got, expected)
+					}
+				}
+			}
+		})
+	}
+}
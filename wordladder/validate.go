@@ -0,0 +1,157 @@
+package wordladder
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Check the graph's internal bookkeeping, for catching corruption after manual edits.
+// Forests need to have been explored.  Returns an error describing the first problem found:
+// a word filed under the wrong key or length, a neighbor that isn't in the graph, a neighbor that
+// doesn't list the word back (unless the graph is Directed), a word without a forest tag, or a forest whose words aren't all connected.
+func (g *WordGraph) Validate() error {
+	var lengths = make([]int, 0, len(g.Graphs))
+	for l := range g.Graphs {
+		lengths = append(lengths, l)
+	}
+	sort.Ints(lengths)
+
+	for _, l := range lengths {
+		var subgraph = g.Graphs[l]
+		if subgraph == nil {
+			return fmt.Errorf("no subgraph for %v-letter words", l)
+		}
+
+		if subgraph.WordLength != l {
+			return fmt.Errorf("subgraph for %v-letter words thinks they have %v letters", l, subgraph.WordLength)
+		}
+
+		if err := subgraph.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Like WordGraph.Validate, for the words of one length
+func (g *WordGraphOfSameLength) Validate() error {
+	var words = g.Words()
+
+	if len(words) > 0 && g.edgeSettings() != g.exploredWith {
+		return fmt.Errorf("the %v-letter words were explored with other rules, and need exploring again", g.WordLength)
+	}
+
+	for _, word := range words {
+		var node = g.node(word)
+
+		if node == nil {
+			return fmt.Errorf("%q has no node", word)
+		}
+
+		if node.Word != word {
+			return fmt.Errorf("%q is filed under %q", node.Word, word)
+		}
+
+		if wordLength(word) != g.WordLength {
+			return fmt.Errorf("%q is in the %v-letter words", word, g.WordLength)
+		}
+
+		if node.ForestTag <= 0 {
+			return fmt.Errorf("%q has no forest tag", word)
+		}
+
+		for _, neighborWord := range g.neighborsOf(node) {
+			var neighbor = g.node(*neighborWord)
+
+			if neighbor == nil {
+				return fmt.Errorf("%q lists %q as a neighbor, but it isn't in the graph", word, *neighborWord)
+			}
+
+			if !g.Directed && !hasNeighbor(g.neighborsOf(neighbor), word) {
+				return fmt.Errorf("%q lists %q as a neighbor, but not the other way round", word, *neighborWord)
+			}
+
+			if neighbor.ForestTag != node.ForestTag {
+				return fmt.Errorf("neighbors %q and %q are in different forests (%v and %v)", word, *neighborWord, node.ForestTag, neighbor.ForestTag)
+			}
+		}
+	}
+
+	// Neighbors always share a forest, so now make sure no forest is really several pieces.
+	// Each forest should be reachable in full from its first word (ignoring direction).
+	var sizes = g.ForestSizes()
+	var checked = make(map[int]bool)
+
+	for _, word := range words {
+		var tag = g.node(word).ForestTag
+		if checked[tag] {
+			continue
+		}
+		checked[tag] = true
+
+		if reached := len(g.undirectedStepsFrom(word)); reached != sizes[tag] {
+			return fmt.Errorf("forest %v has %v words, but only %v are connected to %q", tag, sizes[tag], reached, word)
+		}
+	}
+
+	return nil
+}
+
+// Is word in a list of neighbors?
+func hasNeighbor(neighbors []*string, word string) bool {
+	for _, neighborWord := range neighbors {
+		if *neighborWord == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Like stepsFrom, but following edges either way, so it covers a whole forest even in a directed graph
+func (g *WordGraphOfSameLength) undirectedStepsFrom(word string) map[string]int {
+	if !g.Directed {
+		return g.stepsFrom(word)
+	}
+
+	var incoming = g.incomingEdges()
+
+	var retval = map[string]int{word: 0}
+	var layer = []string{word}
+
+	for steps := 1; len(layer) > 0; steps++ {
+		var nextLayer = []string{}
+
+		for _, w := range layer {
+			var next = incoming[w]
+			for _, neighborWord := range g.neighborsOf(g.node(w)) {
+				next = append(next, *neighborWord)
+			}
+
+			for _, n := range next {
+				if _, seen := retval[n]; !seen {
+					retval[n] = steps
+					nextLayer = append(nextLayer, n)
+				}
+			}
+		}
+
+		layer = nextLayer
+	}
+
+	return retval
+}
+
+// The words with an edge into each word.  Only differs from Neighbors in a Directed graph.
+func (g *WordGraphOfSameLength) incomingEdges() map[string][]string {
+	var retval = make(map[string][]string)
+
+	g.eachNode(func(v *WordNode) {
+		for _, neighborWord := range g.neighborsOf(v) {
+			retval[*neighborWord] = append(retval[*neighborWord], v.Word)
+		}
+	})
+
+	return retval
+}
@@ -0,0 +1,72 @@
+package wordladder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	var tests = []struct {
+		name   string
+		damage func(g *WordGraphOfSameLength)
+		err    string // Part of the error, empty if it should pass
+	}{
+		{"untouched", func(g *WordGraphOfSameLength) {}, ""},
+		{"one-sided neighbor", func(g *WordGraphOfSameLength) {
+			// cot forgets cat, but cat still lists cot
+			var cot = g.WordGraph["cot"]
+			cot.Neighbors = removeNeighbor(cot.Neighbors, "cat")
+		}, "not the other way round"},
+		{"missing neighbor", func(g *WordGraphOfSameLength) {
+			var word = "cut"
+			g.WordGraph["cat"].Neighbors = append(g.WordGraph["cat"].Neighbors, &word)
+		}, "isn't in the graph"},
+		{"no forest", func(g *WordGraphOfSameLength) {
+			// Added behind the graph's back, so never explored
+			g.WordGraph["zzz"] = &WordNode{Word: "zzz"}
+		}, "no forest tag"},
+		{"neighbors in different forests", func(g *WordGraphOfSameLength) {
+			g.WordGraph["dog"].ForestTag = 99
+		}, "different forests"},
+		{"forest in pieces", func(g *WordGraphOfSameLength) {
+			// Cut every edge to dog and cog, but leave them in the same forest as the rest
+			for _, word := range []string{"cot", "dot"} {
+				var node = g.WordGraph[word]
+				node.Neighbors = removeNeighbor(removeNeighbor(node.Neighbors, "cog"), "dog")
+			}
+			g.WordGraph["cog"].Neighbors = removeNeighbor(g.WordGraph["cog"].Neighbors, "cot")
+			g.WordGraph["dog"].Neighbors = removeNeighbor(g.WordGraph["dog"].Neighbors, "dot")
+		}, "only 6 are connected"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var g = NewWordGraphFromWords(fixtureCatDog)
+			test.damage(g.Graphs[3])
+
+			var err = g.Validate()
+
+			if test.err == "" {
+				if err != nil {
+					t.Errorf("got %v, expected no error", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("got %v, expected an error about %q", err, test.err)
+			}
+		})
+	}
+}
+
+// A copy of neighbors without word
+func removeNeighbor(neighbors []*string, word string) []*string {
+	var retval = []*string{}
+	for _, neighborWord := range neighbors {
+		if *neighborWord != word {
+			retval = append(retval, neighborWord)
+		}
+	}
+	return retval
+}
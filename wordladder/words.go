@@ -0,0 +1,146 @@
+package wordladder
+
+import (
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Will we import this word from the word list into our forest graph?
+func IsValidWord(s *string) bool {
+	for _, c := range *s {
+		// Skip words with non-letters
+		if !unicode.IsLetter(c) {
+			return false
+		}
+
+		// Skip words with capitals
+		if !unicode.IsLower(c) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// How many letters are in a word.  Counts runes rather than bytes, so "café" has four.
+func wordLength(word string) int {
+	return utf8.RuneCountInString(word)
+}
+
+// Are two words exactly one change apart?  A word isn't its own neighbor.
+func areNeighbors(s1 string, s2 string) bool {
+	return distance(s1, s2) == 1
+}
+
+// How many changes are needed to go from one word to another?
+// Compares letter by letter (runes, not bytes), without allocating -- this is in the neighbor scan's inner loop.
+func distance(s1 string, s2 string) int {
+	if wordLength(s1) != wordLength(s2) {
+		// Impossible
+		return math.MaxInt32
+	}
+
+	var retval = 0
+
+	for len(s1) > 0 {
+		var r1, n1 = utf8.DecodeRuneInString(s1)
+		var r2, n2 = utf8.DecodeRuneInString(s2)
+
+		if r1 != r2 {
+			retval++
+		}
+
+		s1, s2 = s1[n1:], s2[n2:]
+	}
+
+	return retval
+}
+
+// Do two words have the same letters at each of the given positions (counted in runes, from 0)?
+// Positions past the end of either word are ignored.
+func sameLettersAt(s1 string, s2 string, positions []int) bool {
+	var letters1 = []rune(s1)
+	var letters2 = []rune(s2)
+
+	for _, i := range positions {
+		if i < 0 || i >= len(letters1) || i >= len(letters2) {
+			continue
+		}
+
+		if letters1[i] != letters2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The letters a-z, the alphabet used when none is given
+var DefaultAlphabet = []rune("abcdefghijklmnopqrstuvwxyz")
+
+// Every string one letter different from word, over the letters a-z, whether or not it's a dictionary
+// word: 25 per letter for a lowercase a-z word.  The word itself isn't included.
+// Handy for "did you mean" suggestions and searches that can leave the dictionary.
+func GenerateCandidates(word string) []string {
+	return GenerateCandidatesFrom(word, DefaultAlphabet)
+}
+
+// Like GenerateCandidates, but changing letters to those in alphabet instead of a-z
+func GenerateCandidatesFrom(word string, alphabet []rune) []string {
+	var letters = []rune(word)
+	var retval = make([]string, 0, len(alphabet)*len(letters))
+
+	for i, original := range letters {
+		for _, c := range alphabet {
+			if c == original {
+				continue
+			}
+
+			letters[i] = c
+			retval = append(retval, string(letters))
+		}
+
+		letters[i] = original
+	}
+
+	return retval
+}
+
+// Is every letter of word in alphabet?
+func inAlphabet(word string, alphabet []rune) bool {
+	for _, c := range word {
+		var found = false
+
+		for _, a := range alphabet {
+			if c == a {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Append one of word's wildcard patterns to dst: the word with its i'th letter replaced by "_", so
+// "cat" has "_at", "c_t" and "ca_".  Two words are neighbors exactly when they share a pattern.
+// Appending lets lookups reuse one buffer rather than allocate every pattern.
+func appendWildcardPattern(dst []byte, word string, i int) []byte {
+	var pos = 0
+
+	for _, r := range word {
+		if pos == i {
+			dst = append(dst, '_')
+		} else {
+			dst = utf8.AppendRune(dst, r)
+		}
+		pos++
+	}
+
+	return dst
+}
@@ -0,0 +1,131 @@
+package wordladder
+
+import (
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	var tests = []struct {
+		s1, s2    string
+		distance  int
+		neighbors bool
+	}{
+		{"cat", "cat", 0, false},
+		{"cat", "cot", 1, true},
+		{"cat", "dog", 3, false},
+		{"cat", "cats", math.MaxInt32, false},
+		{"café", "cafe", 1, true}, // é is two bytes, but one letter
+		{"café", "cafés", math.MaxInt32, false},
+		{"кот", "кит", 1, true},
+		{"кот", "cat", 3, false},
+		{"éa", "ée", 1, true},
+	}
+
+	for _, test := range tests {
+		if got := distance(test.s1, test.s2); got != test.distance {
+			t.Errorf("distance(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.distance)
+		}
+
+		if got := areNeighbors(test.s1, test.s2); got != test.neighbors {
+			t.Errorf("areNeighbors(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.neighbors)
+		}
+	}
+}
+
+func TestUnicodeLadder(t *testing.T) {
+	var g = NewWordGraphFromWords([]string{"café", "cafe", "cafard", "кот", "кит", "кил"})
+
+	var tests = []struct {
+		s1, s2 string
+		want   []string
+	}{
+		{"café", "cafe", []string{"café", "cafe"}},
+		{"кот", "кил", []string{"кот", "кит", "кил"}},
+	}
+
+	for _, test := range tests {
+		if got := g.ShortestPath(test.s1, test.s2); !samePath(got, test.want) {
+			t.Errorf("ShortestPath(%v, %v): got %v, expected %v", test.s1, test.s2, got, test.want)
+		}
+	}
+
+	if got := g.GetTotalDistinctWordLengths(); got != 3 {
+		t.Errorf("got %v word lengths, expected 3 (counted in letters)", got)
+	}
+}
+
+func TestGenerateCandidates(t *testing.T) {
+	var tests = []struct {
+		word string
+	}{
+		{"cat"},
+		{"a"},
+		{"plant"},
+		{""},
+	}
+
+	for _, test := range tests {
+		var candidates = GenerateCandidates(test.word)
+
+		if len(candidates) != 25*len(test.word) {
+			t.Errorf("%q: got %v candidates, expected %v", test.word, len(candidates), 25*len(test.word))
+		}
+
+		var seen = make(map[string]bool)
+		for _, candidate := range candidates {
+			if candidate == test.word {
+				t.Errorf("%q: the word itself is a candidate", test.word)
+			}
+
+			if seen[candidate] {
+				t.Errorf("%q: %v is in there twice", test.word, candidate)
+			}
+			seen[candidate] = true
+
+			if !areNeighbors(test.word, candidate) {
+				t.Errorf("%q: %v isn't one change away", test.word, candidate)
+			}
+		}
+	}
+
+	// Every neighbor is in there
+	var candidates = GenerateCandidates("cat")
+	for _, neighbor := range []string{"bat", "cot", "cab", "zat", "caz"} {
+		if !slices.Contains(candidates, neighbor) {
+			t.Errorf("%v is missing from cat's candidates", neighbor)
+		}
+	}
+}
+
+func TestGenerateCandidatesFrom(t *testing.T) {
+	var alphabet = []rune("añé")
+
+	var tests = []struct {
+		word string
+		want []string
+	}{
+		{"aa", []string{"ña", "éa", "añ", "aé"}},
+		{"ñé", []string{"aé", "éé", "ña", "ññ"}},
+		{"b", []string{"a", "ñ", "é"}}, // Not in the alphabet itself, so every letter is a change
+	}
+
+	for _, test := range tests {
+		var got = GenerateCandidatesFrom(test.word, alphabet)
+
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %v, expected %v", test.word, got, test.want)
+		}
+
+		for _, candidate := range got {
+			for _, c := range candidate {
+				if !slices.Contains(alphabet, c) && !strings.ContainsRune(test.word, c) {
+					t.Errorf("%q: %v uses %q, which isn't in the alphabet", test.word, candidate, c)
+				}
+			}
+		}
+	}
+}